@@ -214,7 +214,7 @@ export const useAuthStore = defineStore('auth', () => {
     }
 
     try {
-      const response = await fetch(`${API_BASE}/users/refresh`, {
+      const response = await fetch(`${API_BASE}/auth/refresh`, {
         method: 'POST',
         headers: {
           'Content-Type': 'application/json',
@@ -229,9 +229,9 @@ export const useAuthStore = defineStore('auth', () => {
 
       const data = await response.json()
 
-      // Update access token
-      accessToken.value = data.access_token
-      localStorage.setItem('access_token', data.access_token)
+      // Update access token (backend returns 'token' instead of 'access_token')
+      accessToken.value = data.token || data.access_token
+      localStorage.setItem('access_token', accessToken.value)
 
       // Update refresh token if provided
       if (data.refresh_token) {
@@ -239,7 +239,7 @@ export const useAuthStore = defineStore('auth', () => {
         localStorage.setItem('refresh_token', data.refresh_token)
       }
 
-      return data.access_token
+      return accessToken.value
     } catch (err) {
       console.error('Refresh token error:', err)
       logout()
@@ -248,6 +248,18 @@ export const useAuthStore = defineStore('auth', () => {
   }
 
   function logout() {
+    // Revoke tokens server-side; local state is cleared regardless of the outcome
+    if (accessToken.value) {
+      fetch(`${API_BASE}/auth/logout`, {
+        method: 'POST',
+        headers: {
+          'Content-Type': 'application/json',
+          'Authorization': `Bearer ${accessToken.value}`,
+        },
+        body: JSON.stringify({ refresh_token: refreshToken.value }),
+      }).catch(() => {})
+    }
+
     // Clear state
     user.value = null
     accessToken.value = null
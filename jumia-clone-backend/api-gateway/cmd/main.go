@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
 
 	"jumia-clone-backend/api-gateway/internal/client"
 	"jumia-clone-backend/api-gateway/internal/handler"
@@ -50,17 +51,26 @@ func main() {
 	// Create Gin router
 	router := gin.Default()
 
+	// Client IPs drive the login throttle, so X-Forwarded-For is only
+	// believed when it comes from a configured proxy
+	if err := router.SetTrustedProxies(parseList(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Configure CORS for frontend
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:5173"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-Cart-Token"},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowCredentials: true,
 	}))
 
 	// Register routes for all services
-	handler.RegisterAllRoutes(router, userClient.Conn, productClient.Conn, cartClient.Conn, orderClient.Conn)
+	handler.RegisterAllRoutes(router, userClient.Conn, productClient.Conn, cartClient.Conn, orderClient.Conn, handler.RouteConfig{
+		RequireVerifiedEmail: getEnv("REQUIRE_VERIFIED_EMAIL", "false") == "true",
+		RequireStaffTOTP:     getEnv("REQUIRE_STAFF_TOTP", "false") == "true",
+	})
 
 	// Start HTTP server
 	log.Printf("API Gateway starting on port %s...", port)
@@ -83,3 +93,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// parseList splits a comma-separated value, or returns nil if it is empty
+func parseList(value string) []string {
+	var result []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
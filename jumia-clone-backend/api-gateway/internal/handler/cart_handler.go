@@ -11,6 +11,9 @@ import (
 	"google.golang.org/grpc"
 )
 
+// cartTokenHeader carries the cart token of a guest cart
+const cartTokenHeader = "X-Cart-Token"
+
 type CartHandler struct {
 	client pb.CartServiceClient
 }
@@ -28,6 +31,12 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 		return
 	}
 
+	userID, ok := authorizedUserID(c, req.UserId)
+	if !ok {
+		return
+	}
+	req.UserId = userID
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -37,6 +46,11 @@ func (h *CartHandler) AddToCart(c *gin.Context) {
 		return
 	}
 
+	if resp.QuantityError != nil {
+		respondQuantityError(c, resp.Message, resp.QuantityError)
+		return
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -47,6 +61,12 @@ func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 		return
 	}
 
+	userID, ok := authorizedUserID(c, req.UserId)
+	if !ok {
+		return
+	}
+	req.UserId = userID
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -56,6 +76,11 @@ func (h *CartHandler) UpdateCartItem(c *gin.Context) {
 		return
 	}
 
+	if resp.QuantityError != nil {
+		respondQuantityError(c, resp.Message, resp.QuantityError)
+		return
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -66,6 +91,12 @@ func (h *CartHandler) RemoveFromCart(c *gin.Context) {
 		return
 	}
 
+	userID, ok := authorizedUserID(c, req.UserId)
+	if !ok {
+		return
+	}
+	req.UserId = userID
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -79,7 +110,10 @@ func (h *CartHandler) RemoveFromCart(c *gin.Context) {
 }
 
 func (h *CartHandler) GetCart(c *gin.Context) {
-	userID := c.Param("user_id")
+	userID, ok := authorizedUserID(c, c.Param("user_id"))
+	if !ok {
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -96,7 +130,10 @@ func (h *CartHandler) GetCart(c *gin.Context) {
 }
 
 func (h *CartHandler) ClearCart(c *gin.Context) {
-	userID := c.Param("user_id")
+	userID, ok := authorizedUserID(c, c.Param("user_id"))
+	if !ok {
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -111,3 +148,251 @@ func (h *CartHandler) ClearCart(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+// MergeCart moves the items of the guest cart in the X-Cart-Token header into
+// the authenticated user's cart. Login does this automatically when the
+// header is sent with the login request.
+func (h *CartHandler) MergeCart(c *gin.Context) {
+	token := c.GetHeader(cartTokenHeader)
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": cartTokenHeader + " header required"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.MergeCarts(ctx, &pb.MergeCartsRequest{
+		UserId:    c.GetString("user_id"),
+		CartToken: token,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ApplyCoupon puts a coupon on the authenticated user's cart. Refused
+// coupons are answered like refused quantities, with a code saying why.
+func (h *CartHandler) ApplyCoupon(c *gin.Context) {
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.client.ApplyCoupon(ctx, &pb.ApplyCouponRequest{
+		UserId: c.GetString("user_id"),
+		Code:   req.Code,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if resp.CouponError != nil {
+		respondCouponError(c, resp.Message, resp.CouponError)
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RemoveCoupon takes the coupon off the authenticated user's cart
+func (h *CartHandler) RemoveCoupon(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.client.RemoveCoupon(ctx, &pb.RemoveCouponRequest{
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateGuestCart starts a cart for a visitor who is not logged in. The
+// returned cart_token must be sent in the X-Cart-Token header of the guest
+// cart routes.
+func (h *CartHandler) CreateGuestCart(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.CreateGuestCart(ctx, &pb.CreateGuestCartRequest{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *CartHandler) AddToGuestCart(c *gin.Context) {
+	var req pb.AddToCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, ok := guestCartToken(c)
+	if !ok {
+		return
+	}
+	req.UserId = ""
+	req.CartToken = token
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.AddToCart(ctx, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if resp.QuantityError != nil {
+		respondQuantityError(c, resp.Message, resp.QuantityError)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CartHandler) UpdateGuestCartItem(c *gin.Context) {
+	var req pb.UpdateCartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, ok := guestCartToken(c)
+	if !ok {
+		return
+	}
+	req.UserId = ""
+	req.CartToken = token
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.UpdateCartItem(ctx, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if resp.QuantityError != nil {
+		respondQuantityError(c, resp.Message, resp.QuantityError)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CartHandler) RemoveFromGuestCart(c *gin.Context) {
+	var req pb.RemoveFromCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, ok := guestCartToken(c)
+	if !ok {
+		return
+	}
+	req.UserId = ""
+	req.CartToken = token
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.RemoveFromCart(ctx, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CartHandler) GetGuestCart(c *gin.Context) {
+	token, ok := guestCartToken(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.GetCart(ctx, &pb.GetCartRequest{
+		CartToken: token,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CartHandler) ClearGuestCart(c *gin.Context) {
+	token, ok := guestCartToken(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.ClearCart(ctx, &pb.ClearCartRequest{
+		CartToken: token,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondQuantityError answers a refused cart quantity: 409 Conflict when
+// there is not enough stock, which may change, and 422 Unprocessable Entity
+// for quantities that are never allowed
+func respondQuantityError(c *gin.Context, message string, quantityErr *pb.QuantityErrorData) {
+	code := http.StatusUnprocessableEntity
+	if quantityErr.Code == "insufficient_stock" {
+		code = http.StatusConflict
+	}
+
+	c.JSON(code, gin.H{
+		"error":        message,
+		"code":         quantityErr.Code,
+		"product_id":   quantityErr.ProductId,
+		"max_quantity": quantityErr.MaxQuantity,
+	})
+}
+
+// guestCartToken returns the cart token of a guest cart request, rejecting
+// the request with 400 when it has none
+func guestCartToken(c *gin.Context) (string, bool) {
+	token := c.GetHeader(cartTokenHeader)
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": cartTokenHeader + " header required"})
+		return "", false
+	}
+	return token, true
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	pb "jumia-clone-backend/api-gateway/proto"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// CouponHandler manages coupons (admin only), which order service keeps
+type CouponHandler struct {
+	client pb.CouponServiceClient
+}
+
+func NewCouponHandler(conn *grpc.ClientConn) *CouponHandler {
+	return &CouponHandler{
+		client: pb.NewCouponServiceClient(conn),
+	}
+}
+
+// couponRequest is the body of the create and update coupon routes
+type couponRequest struct {
+	Code         string   `json:"code"`
+	Description  string   `json:"description"`
+	Type         string   `json:"type" binding:"required"`
+	Value        float64  `json:"value"`
+	MinBasket    float64  `json:"min_basket"`
+	UsageLimit   int32    `json:"usage_limit"`
+	PerUserLimit int32    `json:"per_user_limit"`
+	StartsAt     string   `json:"starts_at"`
+	ExpiresAt    string   `json:"expires_at"`
+	Categories   []string `json:"categories"`
+	Brands       []string `json:"brands"`
+	Active       *bool    `json:"active"` // Defaults to true
+}
+
+func (r *couponRequest) spec() *pb.CouponSpec {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+
+	return &pb.CouponSpec{
+		Code:         r.Code,
+		Description:  r.Description,
+		Type:         r.Type,
+		Value:        r.Value,
+		MinBasket:    r.MinBasket,
+		UsageLimit:   r.UsageLimit,
+		PerUserLimit: r.PerUserLimit,
+		StartsAt:     r.StartsAt,
+		ExpiresAt:    r.ExpiresAt,
+		Categories:   r.Categories,
+		Brands:       r.Brands,
+		Active:       active,
+	}
+}
+
+func (h *CouponHandler) CreateCoupon(c *gin.Context) {
+	var req couponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.CreateCoupon(ctx, &pb.CreateCouponRequest{
+		Coupon: req.spec(),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// UpdateCoupon replaces the terms of a coupon; its code cannot change
+func (h *CouponHandler) UpdateCoupon(c *gin.Context) {
+	var req couponRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.UpdateCoupon(ctx, &pb.UpdateCouponRequest{
+		Id:     c.Param("id"),
+		Coupon: req.spec(),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CouponHandler) GetCoupon(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.GetCoupon(ctx, &pb.GetCouponRequest{
+		Id: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *CouponHandler) ListCoupons(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.ListCoupons(ctx, &pb.ListCouponsRequest{
+		Page:     int32(page),
+		PageSize: int32(pageSize),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// respondCouponError answers a refused coupon with 422 Unprocessable Entity
+// and a code saying why it was refused
+func respondCouponError(c *gin.Context, message string, couponErr *pb.CouponErrorData) {
+	body := gin.H{
+		"error": message,
+		"code":  couponErr.Code,
+	}
+	if couponErr.MinBasket > 0 {
+		body["min_basket"] = couponErr.MinBasket
+	}
+	c.JSON(http.StatusUnprocessableEntity, body)
+}
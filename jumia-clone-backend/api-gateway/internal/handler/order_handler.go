@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 )
 
 type OrderHandler struct {
@@ -29,6 +30,12 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	userID, ok := authorizedUserID(c, req.UserId)
+	if !ok {
+		return
+	}
+	req.UserId = userID
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -38,6 +45,11 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	if resp.CouponError != nil {
+		respondCouponError(c, resp.Message, resp.CouponError)
+		return
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -60,11 +72,18 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 		return
 	}
 
+	if _, ok := authorizedUserID(c, resp.Order.UserId); !ok {
+		return
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
 func (h *OrderHandler) ListOrders(c *gin.Context) {
-	userID := c.Query("user_id")
+	userID, ok := authorizedUserID(c, c.Query("user_id"))
+	if !ok {
+		return
+	}
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 
@@ -88,6 +107,7 @@ func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 	orderID := c.Param("id")
 	var req struct {
 		Status string `json:"status" binding:"required"`
+		Reason string `json:"reason"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -100,9 +120,11 @@ func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 	resp, err := h.client.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{
 		OrderId: orderID,
 		Status:  req.Status,
+		Actor:   c.GetString("user_id"),
+		Reason:  req.Reason,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
 		return
 	}
 
@@ -112,24 +134,113 @@ func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 func (h *OrderHandler) CancelOrder(c *gin.Context) {
 	orderID := c.Param("id")
 	var req struct {
-		UserID string `json:"user_id" binding:"required"`
+		UserID string `json:"user_id"`
+		Reason string `json:"reason"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	userID, ok := authorizedUserID(c, req.UserID)
+	if !ok {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	resp, err := h.client.CancelOrder(ctx, &pb.CancelOrderRequest{
 		OrderId: orderID,
-		UserId:  req.UserID,
+		UserId:  userID,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *OrderHandler) GetOrderHistory(c *gin.Context) {
+	orderID := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	orderResp, err := h.client.GetOrder(ctx, &pb.GetOrderRequest{
+		OrderId: orderID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !orderResp.Success {
+		c.JSON(http.StatusNotFound, orderResp)
+		return
+	}
+
+	if _, ok := authorizedUserID(c, orderResp.Order.UserId); !ok {
+		return
+	}
+
+	resp, err := h.client.GetOrderHistory(ctx, &pb.GetOrderHistoryRequest{
+		OrderId: orderID,
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if !resp.Success {
+		c.JSON(http.StatusNotFound, resp)
+		return
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
+
+func (h *OrderHandler) Checkout(c *gin.Context) {
+	var req struct {
+		UserID          string `json:"user_id"`
+		AddressID       string `json:"address_id"`
+		ShippingAddress string `json:"shipping_address" binding:"required_without=AddressID"`
+		PaymentMethod   string `json:"payment_method" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := authorizedUserID(c, req.UserID)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := h.client.Checkout(ctx, &pb.CheckoutRequest{
+		UserId:          userID,
+		AddressId:       req.AddressID,
+		ShippingAddress: req.ShippingAddress,
+		PaymentMethod:   req.PaymentMethod,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if resp.CouponError != nil {
+		respondCouponError(c, resp.Message, resp.CouponError)
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
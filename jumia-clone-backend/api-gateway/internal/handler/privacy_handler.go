@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	pb "jumia-clone-backend/api-gateway/proto"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// exportOrdersPageSize is the largest page order service will return
+const exportOrdersPageSize = 100
+
+// PrivacyHandler handles personal data exports and erasure, which span the
+// user, cart and order services
+type PrivacyHandler struct {
+	userClient     pb.UserServiceClient
+	cartClient     pb.CartServiceClient
+	wishlistClient pb.WishlistServiceClient
+	orderClient    pb.OrderServiceClient
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(userConn, cartConn, orderConn *grpc.ClientConn) *PrivacyHandler {
+	return &PrivacyHandler{
+		userClient:     pb.NewUserServiceClient(userConn),
+		cartClient:     pb.NewCartServiceClient(cartConn),
+		wishlistClient: pb.NewWishlistServiceClient(cartConn),
+		orderClient:    pb.NewOrderServiceClient(orderConn),
+	}
+}
+
+// ExportUserData collects everything stored about a user across services
+// into a single JSON archive
+func (h *PrivacyHandler) ExportUserData(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	userResp, err := h.userClient.ExportUserData(ctx, &pb.ExportUserDataRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export user data"})
+		return
+	}
+	if !userResp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": userResp.Message})
+		return
+	}
+
+	cartResp, err := h.cartClient.GetCart(ctx, &pb.GetCartRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export cart"})
+		return
+	}
+	if !cartResp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": cartResp.Message})
+		return
+	}
+
+	wishlistsResp, err := h.wishlistClient.ListWishlists(ctx, &pb.ListWishlistsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export wishlists"})
+		return
+	}
+	if !wishlistsResp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": wishlistsResp.Message})
+		return
+	}
+
+	var orders []*pb.OrderData
+	for page := int32(1); ; page++ {
+		ordersResp, err := h.orderClient.ListOrders(ctx, &pb.ListOrdersRequest{
+			UserId:   userID,
+			Page:     page,
+			PageSize: exportOrdersPageSize,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to export orders"})
+			return
+		}
+		if !ordersResp.Success {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": ordersResp.Message})
+			return
+		}
+
+		orders = append(orders, ordersResp.Orders...)
+		if len(ordersResp.Orders) < exportOrdersPageSize || int32(len(orders)) >= ordersResp.Total {
+			break
+		}
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="user-`+userID+`-export.json"`)
+	c.JSON(http.StatusOK, gin.H{
+		"exported_at":    time.Now().UTC().Format(time.RFC3339),
+		"user":           userResp.User,
+		"addresses":      userResp.Addresses,
+		"login_attempts": userResp.LoginAttempts,
+		"identities":     userResp.Identities,
+		"cart":           cartResp.Cart,
+		"wishlists":      wishlistsResp.Wishlists,
+		"orders":         orders,
+	})
+}
+
+// EraseUser permanently erases a user's personal data (admin only). Orders
+// are kept for accounting with their shipping details blanked. The user is
+// deactivated first, so they cannot log in and place orders or fill a cart
+// while their data is erased, and the user record is removed last, so a
+// failed erasure can simply be retried.
+func (h *PrivacyHandler) EraseUser(c *gin.Context) {
+	userID := c.Param("id")
+	if userID == c.GetString("user_id") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot erase your own account"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	deactivateResp, err := h.userClient.DeactivateUser(ctx, &pb.DeactivateUserRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to deactivate user"})
+		return
+	}
+	if !deactivateResp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": deactivateResp.Message})
+		return
+	}
+
+	ordersResp, err := h.orderClient.AnonymizeUserOrders(ctx, &pb.AnonymizeUserOrdersRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if !ordersResp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": ordersResp.Message})
+		return
+	}
+
+	cartResp, err := h.cartClient.EraseUserCart(ctx, &pb.EraseUserCartRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to erase cart"})
+		return
+	}
+	if !cartResp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": cartResp.Message})
+		return
+	}
+
+	wishlistsResp, err := h.wishlistClient.ClearWishlists(ctx, &pb.ClearWishlistsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear wishlists"})
+		return
+	}
+	if !wishlistsResp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": wishlistsResp.Message})
+		return
+	}
+
+	userResp, err := h.userClient.EraseUser(ctx, &pb.EraseUserRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to erase user"})
+		return
+	}
+	if !userResp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": userResp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":           true,
+		"message":           userResp.Message,
+		"orders_anonymized": ordersResp.OrdersAnonymized,
+	})
+}
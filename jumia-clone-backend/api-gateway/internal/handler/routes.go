@@ -5,13 +5,40 @@ import (
 	"google.golang.org/grpc"
 )
 
+// RouteConfig holds options that change how routes are guarded
+type RouteConfig struct {
+	// RequireVerifiedEmail blocks order creation and checkout until the user's email is verified
+	RequireVerifiedEmail bool
+	// RequireStaffTOTP blocks seller and admin routes until the user has
+	// enabled two-factor authentication
+	RequireStaffTOTP bool
+}
+
 // RegisterAllRoutes registers all routes for all microservices
-func RegisterAllRoutes(router *gin.Engine, userConn, productConn, cartConn, orderConn *grpc.ClientConn) {
+func RegisterAllRoutes(router *gin.Engine, userConn, productConn, cartConn, orderConn *grpc.ClientConn, cfg RouteConfig) {
 	// Initialize handlers
-	userHandler := NewUserHandler(userConn)
+	userHandler := NewUserHandler(userConn, cartConn)
 	productHandler := NewProductHandler(productConn)
 	cartHandler := NewCartHandler(cartConn)
+	wishlistHandler := NewWishlistHandler(cartConn)
 	orderHandler := NewOrderHandler(orderConn)
+	couponHandler := NewCouponHandler(orderConn)
+	privacyHandler := NewPrivacyHandler(userConn, cartConn, orderConn)
+
+	// Public token verification keys
+	router.GET("/.well-known/jwks.json", userHandler.JWKS)
+
+	// Placing an order optionally requires a verified email
+	verifiedEmail := gin.HandlerFunc(func(c *gin.Context) { c.Next() })
+	if cfg.RequireVerifiedEmail {
+		verifiedEmail = RequireVerifiedEmail()
+	}
+
+	// Seller and admin routes optionally require two-factor authentication
+	staffTOTP := gin.HandlerFunc(func(c *gin.Context) { c.Next() })
+	if cfg.RequireStaffTOTP {
+		staffTOTP = RequireTOTP()
+	}
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -24,46 +51,117 @@ func RegisterAllRoutes(router *gin.Engine, userConn, productConn, cartConn, orde
 			users.GET("/:id", userHandler.AuthMiddleware(), userHandler.GetUser)
 			users.PUT("/:id", userHandler.AuthMiddleware(), userHandler.UpdateUser)
 			users.DELETE("/:id", userHandler.AuthMiddleware(), userHandler.DeleteUser)
+			users.PUT("/:id/password", userHandler.AuthMiddleware(), userHandler.ChangePassword)
+			users.PUT("/:id/email", userHandler.AuthMiddleware(), userHandler.ChangeEmail)
+			users.POST("/:id/verification-email", userHandler.AuthMiddleware(), userHandler.ResendEmailVerification)
+			users.GET("/:id/addresses", userHandler.AuthMiddleware(), userHandler.ListAddresses)
+			users.POST("/:id/addresses", userHandler.AuthMiddleware(), userHandler.CreateAddress)
+			users.GET("/:id/addresses/:address_id", userHandler.AuthMiddleware(), userHandler.GetAddress)
+			users.PUT("/:id/addresses/:address_id", userHandler.AuthMiddleware(), userHandler.UpdateAddress)
+			users.DELETE("/:id/addresses/:address_id", userHandler.AuthMiddleware(), userHandler.DeleteAddress)
+			users.GET("/:id/export", userHandler.AuthMiddleware(), privacyHandler.ExportUserData)
+			users.POST("/:id/totp", userHandler.AuthMiddleware(), userHandler.EnrollTOTP)
+			users.POST("/:id/totp/confirm", userHandler.AuthMiddleware(), userHandler.ConfirmTOTP)
+			users.DELETE("/:id/totp", userHandler.AuthMiddleware(), userHandler.DisableTOTP)
+			users.POST("/:id/totp/recovery-codes", userHandler.AuthMiddleware(), userHandler.RegenerateRecoveryCodes)
+			users.PUT("/:id/role", userHandler.AuthMiddleware(), RequireRole(RoleAdmin), staffTOTP, userHandler.UpdateUserRole)
 		}
 
 		// Auth routes
 		auth := v1.Group("/auth")
 		{
 			auth.POST("/verify", userHandler.VerifyToken)
+			auth.POST("/refresh", userHandler.RefreshToken)
+			auth.POST("/logout", userHandler.AuthMiddleware(), userHandler.Logout)
+			auth.POST("/forgot-password", userHandler.ForgotPassword)
+			auth.POST("/reset-password", userHandler.ResetPassword)
+			auth.POST("/confirm-email-change", userHandler.ConfirmEmailChange)
+			auth.POST("/verify-email", userHandler.VerifyEmail)
+			auth.POST("/totp/verify", userHandler.VerifyTOTP)
+			auth.GET("/oidc/providers", userHandler.ListOIDCProviders)
+			auth.GET("/oidc/:provider/login", userHandler.StartOIDCLogin)
+			auth.GET("/oidc/:provider/callback", userHandler.FinishOIDCLogin)
 		}
 
 		// Product routes
 		products := v1.Group("/products")
 		{
-			products.POST("", productHandler.CreateProduct)
+			products.POST("", userHandler.AuthMiddleware(), RequireRole(RoleSeller, RoleAdmin), staffTOTP, productHandler.CreateProduct)
 			products.GET("", productHandler.ListProducts)
 			products.GET("/search", productHandler.SearchProducts)
 			products.GET("/category", productHandler.GetProductsByCategory)
 			products.GET("/top-deals", productHandler.GetTopDeals)
 			products.GET("/deals", productHandler.GetDealsByType)
 			products.GET("/:id", productHandler.GetProduct)
-			products.PUT("/:id", productHandler.UpdateProduct)
-			products.DELETE("/:id", productHandler.DeleteProduct)
+			products.PUT("/:id", userHandler.AuthMiddleware(), RequireRole(RoleSeller, RoleAdmin), staffTOTP, productHandler.UpdateProduct)
+			products.DELETE("/:id", userHandler.AuthMiddleware(), RequireRole(RoleSeller, RoleAdmin), staffTOTP, productHandler.DeleteProduct)
 		}
 
 		// Cart routes
-		cart := v1.Group("/cart")
+		cart := v1.Group("/cart", userHandler.AuthMiddleware())
 		{
 			cart.POST("/add", cartHandler.AddToCart)
 			cart.PUT("/update", cartHandler.UpdateCartItem)
 			cart.POST("/remove", cartHandler.RemoveFromCart)
 			cart.GET("/:user_id", cartHandler.GetCart)
 			cart.DELETE("/:user_id", cartHandler.ClearCart)
+			cart.POST("/merge", cartHandler.MergeCart)
+			cart.POST("/coupon", cartHandler.ApplyCoupon)
+			cart.DELETE("/coupon", cartHandler.RemoveCoupon)
+		}
+
+		// Guest cart routes, identified by the X-Cart-Token header
+		guestCart := v1.Group("/guest-cart")
+		{
+			guestCart.POST("", cartHandler.CreateGuestCart)
+			guestCart.GET("", cartHandler.GetGuestCart)
+			guestCart.POST("/add", cartHandler.AddToGuestCart)
+			guestCart.PUT("/update", cartHandler.UpdateGuestCartItem)
+			guestCart.POST("/remove", cartHandler.RemoveFromGuestCart)
+			guestCart.DELETE("", cartHandler.ClearGuestCart)
+		}
+
+		// Wishlist routes; "default" names the user's default wishlist
+		wishlist := v1.Group("/wishlist", userHandler.AuthMiddleware())
+		{
+			wishlist.GET("", wishlistHandler.ListWishlists)
+			wishlist.POST("", wishlistHandler.CreateWishlist)
+			wishlist.GET("/price-drops", wishlistHandler.ListPriceDrops)
+			wishlist.POST("/items", wishlistHandler.AddToWishlist)
+			wishlist.POST("/move-to-cart", wishlistHandler.MoveToCart)
+			wishlist.POST("/move-from-cart", wishlistHandler.MoveToWishlist)
+			wishlist.GET("/:id", wishlistHandler.GetWishlist)
+			wishlist.PUT("/:id", wishlistHandler.RenameWishlist)
+			wishlist.DELETE("/:id", wishlistHandler.DeleteWishlist)
+			wishlist.DELETE("/:id/items/:product_id", wishlistHandler.RemoveFromWishlist)
 		}
 
 		// Order routes
-		orders := v1.Group("/orders")
+		orders := v1.Group("/orders", userHandler.AuthMiddleware())
 		{
-			orders.POST("", orderHandler.CreateOrder)
+			orders.POST("", verifiedEmail, orderHandler.CreateOrder)
 			orders.GET("", orderHandler.ListOrders)
 			orders.GET("/:id", orderHandler.GetOrder)
-			orders.PUT("/:id/status", orderHandler.UpdateOrderStatus)
+			orders.GET("/:id/history", orderHandler.GetOrderHistory)
+			orders.PUT("/:id/status", RequireRole(RoleSeller, RoleAdmin), staffTOTP, orderHandler.UpdateOrderStatus)
 			orders.POST("/:id/cancel", orderHandler.CancelOrder)
 		}
+
+		// Checkout route
+		v1.POST("/checkout", userHandler.AuthMiddleware(), verifiedEmail, orderHandler.Checkout)
+
+		// Admin routes
+		admin := v1.Group("/admin", userHandler.AuthMiddleware(), RequireRole(RoleAdmin), staffTOTP)
+		{
+			admin.GET("/users", userHandler.ListUsers)
+			admin.POST("/users/:id/deactivate", userHandler.DeactivateUser)
+			admin.POST("/users/:id/reactivate", userHandler.ReactivateUser)
+			admin.POST("/users/:id/erase", privacyHandler.EraseUser)
+			admin.GET("/login-attempts", userHandler.ListLoginAttempts)
+			admin.GET("/coupons", couponHandler.ListCoupons)
+			admin.POST("/coupons", couponHandler.CreateCoupon)
+			admin.GET("/coupons/:id", couponHandler.GetCoupon)
+			admin.PUT("/coupons/:id", couponHandler.UpdateCoupon)
+		}
 	}
 }
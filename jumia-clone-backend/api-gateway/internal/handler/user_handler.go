@@ -2,7 +2,13 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,18 +21,25 @@ import (
 // UserHandler handles HTTP requests for user operations
 type UserHandler struct {
 	userClient pb.UserServiceClient
+	// cartClient merges guest carts on login; nil when cart service is not wired in
+	cartClient pb.CartServiceClient
 }
 
-// NewUserHandler creates a new user handler
-func NewUserHandler(conn *grpc.ClientConn) *UserHandler {
-	return &UserHandler{
+// NewUserHandler creates a new user handler. cartConn may be nil, in which
+// case guest carts are not merged on login.
+func NewUserHandler(conn, cartConn *grpc.ClientConn) *UserHandler {
+	h := &UserHandler{
 		userClient: pb.NewUserServiceClient(conn),
 	}
+	if cartConn != nil {
+		h.cartClient = pb.NewCartServiceClient(cartConn)
+	}
+	return h
 }
 
 // RegisterRoutes registers all routes for the API gateway
 func RegisterRoutes(router *gin.Engine, userConn *grpc.ClientConn) {
-	userHandler := NewUserHandler(userConn)
+	userHandler := NewUserHandler(userConn, nil)
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
@@ -112,38 +125,64 @@ func (h *UserHandler) Login(c *gin.Context) {
 	defer cancel()
 
 	resp, err := h.userClient.Login(ctx, &pb.LoginRequest{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		IpAddress: c.ClientIP(),
 	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to login"})
 		return
 	}
 
+	if resp.RetryAfterSeconds > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(resp.RetryAfterSeconds)))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": resp.Message})
+		return
+	}
+
 	if !resp.Success {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": resp.Message})
 		return
 	}
 
+	// The password was right but a second factor is needed before any
+	// tokens are issued; the client continues with POST /auth/totp/verify
+	if resp.TotpRequired {
+		c.JSON(http.StatusOK, gin.H{
+			"success":         true,
+			"message":         resp.Message,
+			"totp_required":   true,
+			"challenge_token": resp.ChallengeToken,
+		})
+		return
+	}
+
+	h.mergeGuestCart(c, resp.User.Id)
+
 	c.JSON(http.StatusOK, gin.H{
 		"success":       true,
 		"message":       resp.Message,
 		"token":         resp.Token,
 		"refresh_token": resp.RefreshToken,
 		"user": gin.H{
-			"id":         resp.User.Id,
-			"first_name": resp.User.FirstName,
-			"last_name":  resp.User.LastName,
-			"email":      resp.User.Email,
-			"phone":      resp.User.Phone,
-			"address":    resp.User.Address,
+			"id":             resp.User.Id,
+			"first_name":     resp.User.FirstName,
+			"last_name":      resp.User.LastName,
+			"email":          resp.User.Email,
+			"phone":          resp.User.Phone,
+			"address":        resp.User.Address,
+			"role":           resp.User.Role,
+			"email_verified": resp.User.EmailVerified,
 		},
 	})
 }
 
 // GetUser retrieves user information
 func (h *UserHandler) GetUser(c *gin.Context) {
-	userID := c.Param("id")
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -164,21 +203,27 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"user": gin.H{
-			"id":         resp.User.Id,
-			"first_name": resp.User.FirstName,
-			"last_name":  resp.User.LastName,
-			"email":      resp.User.Email,
-			"phone":      resp.User.Phone,
-			"address":    resp.User.Address,
-			"created_at": resp.User.CreatedAt,
-			"updated_at": resp.User.UpdatedAt,
+			"id":             resp.User.Id,
+			"first_name":     resp.User.FirstName,
+			"last_name":      resp.User.LastName,
+			"email":          resp.User.Email,
+			"phone":          resp.User.Phone,
+			"address":        resp.User.Address,
+			"role":           resp.User.Role,
+			"email_verified": resp.User.EmailVerified,
+			"totp_enabled":   resp.User.TotpEnabled,
+			"created_at":     resp.User.CreatedAt,
+			"updated_at":     resp.User.UpdatedAt,
 		},
 	})
 }
 
 // UpdateUser updates user information
 func (h *UserHandler) UpdateUser(c *gin.Context) {
-	userID := c.Param("id")
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
 
 	var req struct {
 		FirstName string `json:"first_name"`
@@ -226,9 +271,134 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	})
 }
 
+// ChangePassword changes the user's password. Other sessions are logged out
+// and the response carries a new token pair for this one.
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req struct {
+		CurrentPassword string `json:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" binding:"required,min=6"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ChangePassword(ctx, &pb.ChangePasswordRequest{
+		UserId:          userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change password"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       resp.Message,
+		"token":         resp.Token,
+		"refresh_token": resp.RefreshToken,
+	})
+}
+
+// ChangeEmail starts an email change; the new address must be confirmed
+func (h *UserHandler) ChangeEmail(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		NewEmail string `json:"new_email" binding:"required,email"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ChangeEmail(ctx, &pb.ChangeEmailRequest{
+		UserId:   userID,
+		Password: req.Password,
+		NewEmail: req.NewEmail,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change email"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": resp.Message,
+	})
+}
+
+// ConfirmEmailChange applies an email change using the token sent to the new address
+func (h *UserHandler) ConfirmEmailChange(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ConfirmEmailChange(ctx, &pb.ConfirmEmailChangeRequest{
+		Token: req.Token,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to confirm email change"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"user": gin.H{
+			"id":    resp.User.Id,
+			"email": resp.User.Email,
+		},
+	})
+}
+
 // DeleteUser deletes a user
 func (h *UserHandler) DeleteUser(c *gin.Context) {
-	userID := c.Param("id")
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -275,47 +445,1032 @@ func (h *UserHandler) VerifyToken(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"valid":   resp.Valid,
-		"user_id": resp.UserId,
-		"message": resp.Message,
+		"valid":          resp.Valid,
+		"user_id":        resp.UserId,
+		"role":           resp.Role,
+		"email_verified": resp.EmailVerified,
+		"totp_enabled":   resp.TotpEnabled,
+		"message":        resp.Message,
 	})
 }
 
-// AuthMiddleware validates JWT token from Authorization header
-func (h *UserHandler) AuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
-			return
-		}
+// RefreshToken exchanges a refresh token for a new token pair
+func (h *UserHandler) RefreshToken(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
 
-		// Extract token from "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-			c.Abort()
-			return
-		}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-		token := parts[1]
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+	resp, err := h.userClient.RefreshToken(ctx, &pb.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
 
-		resp, err := h.userClient.VerifyToken(ctx, &pb.VerifyTokenRequest{
-			Token: token,
-		})
+	if !resp.Success {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": resp.Message})
+		return
+	}
 
-		if err != nil || !resp.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       resp.Message,
+		"token":         resp.Token,
+		"refresh_token": resp.RefreshToken,
+	})
+}
+
+// Logout revokes the caller's access token, the optional refresh token and,
+// with all_sessions, every other token the user holds
+func (h *UserHandler) Logout(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+		AllSessions  bool   `json:"all_sessions"`
+	}
+
+	// The body is optional; a bare logout only revokes the access token
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+	}
 
-		// Store user ID in context for handlers to use
-		c.Set("user_id", resp.UserId)
-		c.Next()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.Logout(ctx, &pb.LogoutRequest{
+		Token:        c.GetString("token"),
+		RefreshToken: req.RefreshToken,
+		AllSessions:  req.AllSessions,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+	})
+}
+
+// ForgotPassword starts a password reset. It always responds the same way so
+// it cannot be used to discover registered emails.
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{
+		Email: req.Email,
+	})
+	if err != nil || !resp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request password reset"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+	})
+}
+
+// ResetPassword sets a new password using the token from a reset email
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required,min=6"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ResetPassword(ctx, &pb.ResetPasswordRequest{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reset password"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+	})
+}
+
+// VerifyEmail confirms the user's email with the token from the verification email
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
 	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.VerifyEmail(ctx, &pb.VerifyEmailRequest{
+		Token: req.Token,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify email"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"user": gin.H{
+			"id":             resp.User.Id,
+			"email":          resp.User.Email,
+			"email_verified": resp.User.EmailVerified,
+		},
+	})
+}
+
+// ResendEmailVerification sends the user a new verification email
+func (h *UserHandler) ResendEmailVerification(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ResendEmailVerification(ctx, &pb.ResendEmailVerificationRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification email"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+	})
+}
+
+// ListLoginAttempts returns the login attempt audit log (admin only)
+func (h *UserHandler) ListLoginAttempts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ListLoginAttempts(ctx, &pb.ListLoginAttemptsRequest{
+		Email:        c.Query("email"),
+		UserId:       c.Query("user_id"),
+		IpAddress:    c.Query("ip_address"),
+		OnlyFailures: c.Query("failed") == "true",
+		Page:         int32(page),
+		PageSize:     int32(pageSize),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list login attempts"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"attempts": resp.Attempts,
+		"total":    resp.Total,
+	})
+}
+
+// addressRequest is the body for creating or updating an address
+type addressRequest struct {
+	Label         string `json:"label"`
+	RecipientName string `json:"recipient_name" binding:"required"`
+	Phone         string `json:"phone" binding:"required"`
+	Region        string `json:"region" binding:"required"`
+	City          string `json:"city" binding:"required"`
+	Street        string `json:"street" binding:"required"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+func (r *addressRequest) toInput() *pb.AddressInput {
+	return &pb.AddressInput{
+		Label:         r.Label,
+		RecipientName: r.RecipientName,
+		Phone:         r.Phone,
+		Region:        r.Region,
+		City:          r.City,
+		Street:        r.Street,
+		IsDefault:     r.IsDefault,
+	}
+}
+
+// ListAddresses returns the user's saved addresses, default first
+func (h *UserHandler) ListAddresses(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ListAddresses(ctx, &pb.ListAddressesRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list addresses"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"addresses": resp.Addresses,
+	})
+}
+
+// CreateAddress saves a new address for the user
+func (h *UserHandler) CreateAddress(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req addressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.CreateAddress(ctx, &pb.CreateAddressRequest{
+		UserId:  userID,
+		Address: req.toInput(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create address"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"address": resp.Address,
+	})
+}
+
+// GetAddress returns one of the user's addresses
+func (h *UserHandler) GetAddress(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.GetAddress(ctx, &pb.GetAddressRequest{
+		UserId:    userID,
+		AddressId: c.Param("address_id"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get address"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"address": resp.Address,
+	})
+}
+
+// UpdateAddress replaces one of the user's addresses
+func (h *UserHandler) UpdateAddress(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req addressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.UpdateAddress(ctx, &pb.UpdateAddressRequest{
+		UserId:    userID,
+		AddressId: c.Param("address_id"),
+		Address:   req.toInput(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update address"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"address": resp.Address,
+	})
+}
+
+// DeleteAddress removes one of the user's addresses
+func (h *UserHandler) DeleteAddress(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.DeleteAddress(ctx, &pb.DeleteAddressRequest{
+		UserId:    userID,
+		AddressId: c.Param("address_id"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete address"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+	})
+}
+
+// ListUsers returns a filtered, paginated list of users (admin only)
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ListUsers(ctx, &pb.ListUsersRequest{
+		Page:          int32(page),
+		PageSize:      int32(pageSize),
+		Email:         c.Query("email"),
+		Name:          c.Query("name"),
+		Status:        c.Query("status"),
+		CreatedAfter:  c.Query("created_after"),
+		CreatedBefore: c.Query("created_before"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list users"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"users":   resp.Users,
+		"total":   resp.Total,
+	})
+}
+
+// DeactivateUser blocks a user from logging in and logs them out (admin only)
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	userID := c.Param("id")
+	if userID == c.GetString("user_id") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "You cannot deactivate your own account"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.DeactivateUser(ctx, &pb.DeactivateUserRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to deactivate user"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"user":    resp.User,
+	})
+}
+
+// ReactivateUser lets a deactivated user log in again (admin only)
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ReactivateUser(ctx, &pb.ReactivateUserRequest{
+		UserId: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reactivate user"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"user":    resp.User,
+	})
+}
+
+// JWKS publishes the public keys tokens are signed with so other services can
+// verify them locally. Locally verified tokens are not checked against the
+// logout denylist; use VerifyToken where revocation matters.
+func (h *UserHandler) JWKS(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.GetJWKS(ctx, &pb.GetJWKSRequest{})
+	if err != nil || !resp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get signing keys"})
+		return
+	}
+
+	type jsonWebKey struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+	}
+
+	keys := make([]jsonWebKey, 0, len(resp.Keys))
+	for _, key := range resp.Keys {
+		keys = append(keys, jsonWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Alg: key.Alg,
+			Use: key.Use,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
+
+// UpdateUserRole changes a user's role (admin only)
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	userID := c.Param("id")
+
+	var req struct {
+		Role string `json:"role" binding:"required,oneof=customer seller admin"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.UpdateUserRole(ctx, &pb.UpdateUserRoleRequest{
+		UserId: userID,
+		Role:   req.Role,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user role"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+		"user": gin.H{
+			"id":    resp.User.Id,
+			"email": resp.User.Email,
+			"role":  resp.User.Role,
+		},
+	})
+}
+
+// AuthMiddleware validates JWT token from Authorization header
+func (h *UserHandler) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.Abort()
+			return
+		}
+
+		// Extract token from "Bearer <token>"
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			c.Abort()
+			return
+		}
+
+		token := parts[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		resp, err := h.userClient.VerifyToken(ctx, &pb.VerifyTokenRequest{
+			Token: token,
+		})
+
+		if err != nil || !resp.Valid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		// Store user ID, role and the raw token in context for handlers to use
+		c.Set("user_id", resp.UserId)
+		c.Set("role", resp.Role)
+		c.Set("email_verified", resp.EmailVerified)
+		c.Set("totp_enabled", resp.TotpEnabled)
+		c.Set("token", token)
+		c.Next()
+	}
+}
+
+// ListOIDCProviders lists the external identity providers users can log in with
+func (h *UserHandler) ListOIDCProviders(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ListOIDCProviders(ctx, &pb.ListOIDCProvidersRequest{})
+	if err != nil || !resp.Success {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list identity providers"})
+		return
+	}
+
+	providers := make([]gin.H, 0, len(resp.Providers))
+	for _, provider := range resp.Providers {
+		providers = append(providers, gin.H{
+			"name":         provider.Name,
+			"display_name": provider.DisplayName,
+			"login_url":    "/api/v1/auth/oidc/" + provider.Name + "/login",
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"providers": providers,
+	})
+}
+
+// StartOIDCLogin redirects the browser to the identity provider
+func (h *UserHandler) StartOIDCLogin(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.StartOIDCLogin(ctx, &pb.StartOIDCLoginRequest{
+		Provider: c.Param("provider"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	authURL, err := url.Parse(resp.AuthorizationUrl)
+	if err != nil || authURL.Query().Get("state") == "" {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start login"})
+		return
+	}
+
+	// Tie the login to this browser, so a callback URL from someone else's
+	// login cannot sign the browser into their account
+	setOIDCStateCookie(c, hashOIDCState(authURL.Query().Get("state")), oidcStateCookieTTL)
+
+	c.Redirect(http.StatusFound, resp.AuthorizationUrl)
+}
+
+// FinishOIDCLogin handles the identity provider's redirect back and logs the user in
+func (h *UserHandler) FinishOIDCLogin(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             providerErr,
+			"error_description": c.Query("error_description"),
+		})
+		return
+	}
+
+	state := c.Query("state")
+	stateCookie, _ := c.Cookie(oidcStateCookie)
+	setOIDCStateCookie(c, "", -time.Second)
+	if state == "" || subtle.ConstantTimeCompare([]byte(stateCookie), []byte(hashOIDCState(state))) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "login was not started in this browser"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.FinishOIDCLogin(ctx, &pb.FinishOIDCLoginRequest{
+		Provider:  c.Param("provider"),
+		Code:      c.Query("code"),
+		State:     state,
+		IpAddress: c.ClientIP(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to login"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": resp.Message})
+		return
+	}
+
+	if resp.TotpRequired {
+		c.JSON(http.StatusOK, gin.H{
+			"success":         true,
+			"message":         resp.Message,
+			"totp_required":   true,
+			"challenge_token": resp.ChallengeToken,
+		})
+		return
+	}
+
+	h.mergeGuestCart(c, resp.User.Id)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       resp.Message,
+		"token":         resp.Token,
+		"refresh_token": resp.RefreshToken,
+		"created":       resp.Created,
+		"user": gin.H{
+			"id":             resp.User.Id,
+			"first_name":     resp.User.FirstName,
+			"last_name":      resp.User.LastName,
+			"email":          resp.User.Email,
+			"phone":          resp.User.Phone,
+			"address":        resp.User.Address,
+			"role":           resp.User.Role,
+			"email_verified": resp.User.EmailVerified,
+		},
+	})
+}
+
+// VerifyTOTP completes a login that was answered with totp_required
+func (h *UserHandler) VerifyTOTP(c *gin.Context) {
+	var req struct {
+		ChallengeToken string `json:"challenge_token" binding:"required"`
+		Code           string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.VerifyTOTP(ctx, &pb.VerifyTOTPRequest{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		IpAddress:      c.ClientIP(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify code"})
+		return
+	}
+
+	if resp.RetryAfterSeconds > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(resp.RetryAfterSeconds)))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": resp.Message})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": resp.Message})
+		return
+	}
+
+	h.mergeGuestCart(c, resp.User.Id)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"message":       resp.Message,
+		"token":         resp.Token,
+		"refresh_token": resp.RefreshToken,
+		"user": gin.H{
+			"id":             resp.User.Id,
+			"first_name":     resp.User.FirstName,
+			"last_name":      resp.User.LastName,
+			"email":          resp.User.Email,
+			"phone":          resp.User.Phone,
+			"address":        resp.User.Address,
+			"role":           resp.User.Role,
+			"email_verified": resp.User.EmailVerified,
+			"totp_enabled":   resp.User.TotpEnabled,
+		},
+	})
+}
+
+// EnrollTOTP starts two-factor authentication setup and returns the secret
+// for the user's authenticator app
+func (h *UserHandler) EnrollTOTP(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start two-factor authentication setup"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"message":     resp.Message,
+		"secret":      resp.Secret,
+		"otpauth_uri": resp.OtpauthUri,
+	})
+}
+
+// ConfirmTOTP enables two-factor authentication with a code from the
+// authenticator app and returns the recovery codes
+func (h *UserHandler) ConfirmTOTP(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{
+		UserId: userID,
+		Code:   req.Code,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to enable two-factor authentication"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"message":        resp.Message,
+		"recovery_codes": resp.RecoveryCodes,
+	})
+}
+
+// DisableTOTP turns two-factor authentication off
+func (h *UserHandler) DisableTOTP(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.DisableTOTP(ctx, &pb.DisableTOTPRequest{
+		UserId:   userID,
+		Password: req.Password,
+		Code:     req.Code,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable two-factor authentication"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": resp.Message,
+	})
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes
+func (h *UserHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := authorizedUserID(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.userClient.RegenerateRecoveryCodes(ctx, &pb.RegenerateRecoveryCodesRequest{
+		UserId: userID,
+		Code:   req.Code,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to regenerate recovery codes"})
+		return
+	}
+
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":        true,
+		"message":        resp.Message,
+		"recovery_codes": resp.RecoveryCodes,
+	})
+}
+
+// mergeGuestCart moves the guest cart named by the X-Cart-Token header into
+// the cart of the user who just logged in. A failed merge does not fail the
+// login; the guest cart is left for the client to merge later.
+func (h *UserHandler) mergeGuestCart(c *gin.Context, userID string) {
+	token := c.GetHeader(cartTokenHeader)
+	if token == "" || h.cartClient == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.cartClient.MergeCarts(ctx, &pb.MergeCartsRequest{
+		UserId:    userID,
+		CartToken: token,
+	})
+	if err != nil {
+		log.Printf("Failed to merge guest cart for user %s: %v", userID, err)
+		return
+	}
+	if !resp.Success {
+		log.Printf("Failed to merge guest cart for user %s: %s", userID, resp.Message)
+	}
+}
+
+const (
+	// oidcStateCookie holds a hash of the state of the OIDC login the browser started
+	oidcStateCookie    = "oidc_state"
+	oidcStateCookieTTL = 10 * time.Minute
+)
+
+// setOIDCStateCookie sets the login state cookie, or deletes it when maxAge is negative
+func setOIDCStateCookie(c *gin.Context, value string, maxAge time.Duration) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, value, int(maxAge.Seconds()), "/api/v1/auth/oidc", "", secure, true)
+}
+
+func hashOIDCState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
 }
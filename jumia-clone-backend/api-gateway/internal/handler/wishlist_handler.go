@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	pb "jumia-clone-backend/api-gateway/proto"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// defaultWishlistID stands for the user's default wishlist in wishlist routes
+const defaultWishlistID = "default"
+
+// WishlistHandler serves the authenticated user's wishlists, which cart
+// service keeps
+type WishlistHandler struct {
+	client pb.WishlistServiceClient
+}
+
+func NewWishlistHandler(conn *grpc.ClientConn) *WishlistHandler {
+	return &WishlistHandler{
+		client: pb.NewWishlistServiceClient(conn),
+	}
+}
+
+func (h *WishlistHandler) ListWishlists(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.ListWishlists(ctx, &pb.ListWishlistsRequest{
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WishlistHandler) CreateWishlist(c *gin.Context) {
+	var req struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.CreateWishlist(ctx, &pb.CreateWishlistRequest{
+		UserId: c.GetString("user_id"),
+		Name:   req.Name,
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *WishlistHandler) GetWishlist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.GetWishlist(ctx, &pb.GetWishlistRequest{
+		UserId:     c.GetString("user_id"),
+		WishlistId: wishlistParam(c),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WishlistHandler) RenameWishlist(c *gin.Context) {
+	var req struct {
+		Name string `json:"name" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.RenameWishlist(ctx, &pb.RenameWishlistRequest{
+		UserId:     c.GetString("user_id"),
+		WishlistId: wishlistParam(c),
+		Name:       req.Name,
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WishlistHandler) DeleteWishlist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.DeleteWishlist(ctx, &pb.DeleteWishlistRequest{
+		UserId:     c.GetString("user_id"),
+		WishlistId: wishlistParam(c),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AddToWishlist adds a product to the wishlist named by wishlist_id, or to
+// the default wishlist when it is omitted
+func (h *WishlistHandler) AddToWishlist(c *gin.Context) {
+	var req struct {
+		ProductID  string `json:"product_id" binding:"required"`
+		WishlistID string `json:"wishlist_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.AddToWishlist(ctx, &pb.AddToWishlistRequest{
+		UserId:     c.GetString("user_id"),
+		WishlistId: req.WishlistID,
+		ProductId:  req.ProductID,
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *WishlistHandler) RemoveFromWishlist(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.RemoveFromWishlist(ctx, &pb.RemoveFromWishlistRequest{
+		UserId:     c.GetString("user_id"),
+		WishlistId: wishlistParam(c),
+		ProductId:  c.Param("product_id"),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MoveToCart moves a wishlisted product into the user's cart. The cart's
+// quantity limits apply, and the product stays on the wishlist if they
+// refuse the quantity.
+func (h *WishlistHandler) MoveToCart(c *gin.Context) {
+	var req struct {
+		ProductID  string `json:"product_id" binding:"required"`
+		WishlistID string `json:"wishlist_id"`
+		Quantity   int32  `json:"quantity"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.MoveToCart(ctx, &pb.MoveToCartRequest{
+		UserId:     c.GetString("user_id"),
+		WishlistId: req.WishlistID,
+		ProductId:  req.ProductID,
+		Quantity:   req.Quantity,
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	if resp.QuantityError != nil {
+		respondQuantityError(c, resp.Message, resp.QuantityError)
+		return
+	}
+	if !resp.Success {
+		c.JSON(http.StatusBadRequest, gin.H{"error": resp.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MoveToWishlist saves a product in the user's cart for later, on the
+// wishlist named by wishlist_id or the default wishlist
+func (h *WishlistHandler) MoveToWishlist(c *gin.Context) {
+	var req struct {
+		ProductID  string `json:"product_id" binding:"required"`
+		WishlistID string `json:"wishlist_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.MoveToWishlist(ctx, &pb.MoveToWishlistRequest{
+		UserId:     c.GetString("user_id"),
+		WishlistId: req.WishlistID,
+		ProductId:  req.ProductID,
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListPriceDrops returns the user's wishlisted products whose final price has
+// fallen since they were added
+func (h *WishlistHandler) ListPriceDrops(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.client.ListPriceDrops(ctx, &pb.ListPriceDropsRequest{
+		UserId: c.GetString("user_id"),
+	})
+	if err != nil {
+		c.JSON(httpStatusFromGRPC(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// wishlistParam returns the wishlist ID in the route, with "default" mapped
+// to the empty ID cart service uses for the default wishlist
+func wishlistParam(c *gin.Context) string {
+	id := c.Param("id")
+	if id == defaultWishlistID {
+		return ""
+	}
+	return id
+}
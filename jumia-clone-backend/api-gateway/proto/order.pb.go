@@ -28,6 +28,8 @@ type CreateOrderRequest struct {
 	Items           []*OrderItemInput      `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 	ShippingAddress string                 `protobuf:"bytes,3,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
 	PaymentMethod   string                 `protobuf:"bytes,4,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	AddressId       string                 `protobuf:"bytes,5,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"` // Saved address to ship to; takes precedence over shipping_address
+	CouponCode      string                 `protobuf:"bytes,6,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -90,11 +92,26 @@ func (x *CreateOrderRequest) GetPaymentMethod() string {
 	return ""
 }
 
+func (x *CreateOrderRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	Order         *OrderData             `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	CouponError   *CouponErrorData       `protobuf:"bytes,4,opt,name=coupon_error,json=couponError,proto3" json:"coupon_error,omitempty"` // Set when the coupon was refused
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -150,6 +167,13 @@ func (x *CreateOrderResponse) GetOrder() *OrderData {
 	return nil
 }
 
+func (x *CreateOrderResponse) GetCouponError() *CouponErrorData {
+	if x != nil {
+		return x.CouponError
+	}
+	return nil
+}
+
 // Get Order
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -389,6 +413,8 @@ type UpdateOrderStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -437,6 +463,20 @@ func (x *UpdateOrderStatusRequest) GetStatus() string {
 	return ""
 }
 
+func (x *UpdateOrderStatusRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type UpdateOrderStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -502,6 +542,7 @@ type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -550,6 +591,13 @@ func (x *CancelOrderRequest) GetUserId() string {
 	return ""
 }
 
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type CancelOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -602,36 +650,33 @@ func (x *CancelOrderResponse) GetMessage() string {
 	return ""
 }
 
-// Data Models
-type OrderData struct {
+// Checkout
+// Turns the user's cart into an order, with the coupon applied to the cart,
+// and clears the cart once the order is saved.
+type CheckoutRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items           []*OrderItemData       `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	TotalPrice      float64                `protobuf:"fixed64,5,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	ShippingAddress string                 `protobuf:"bytes,6,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
-	PaymentMethod   string                 `protobuf:"bytes,7,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
-	CreatedAt       string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt       string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShippingAddress string                 `protobuf:"bytes,2,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	PaymentMethod   string                 `protobuf:"bytes,3,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	AddressId       string                 `protobuf:"bytes,4,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"` // Saved address to ship to; takes precedence over shipping_address
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
-func (x *OrderData) Reset() {
-	*x = OrderData{}
+func (x *CheckoutRequest) Reset() {
+	*x = CheckoutRequest{}
 	mi := &file_proto_order_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderData) String() string {
+func (x *CheckoutRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderData) ProtoMessage() {}
+func (*CheckoutRequest) ProtoMessage() {}
 
-func (x *OrderData) ProtoReflect() protoreflect.Message {
+func (x *CheckoutRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_order_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -643,101 +688,130 @@ func (x *OrderData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderData.ProtoReflect.Descriptor instead.
-func (*OrderData) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckoutRequest.ProtoReflect.Descriptor instead.
+func (*CheckoutRequest) Descriptor() ([]byte, []int) {
 	return file_proto_order_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *OrderData) GetId() string {
+func (x *CheckoutRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *OrderData) GetUserId() string {
+func (x *CheckoutRequest) GetShippingAddress() string {
 	if x != nil {
-		return x.UserId
+		return x.ShippingAddress
 	}
 	return ""
 }
 
-func (x *OrderData) GetItems() []*OrderItemData {
+func (x *CheckoutRequest) GetPaymentMethod() string {
 	if x != nil {
-		return x.Items
+		return x.PaymentMethod
 	}
-	return nil
+	return ""
 }
 
-func (x *OrderData) GetStatus() string {
+func (x *CheckoutRequest) GetAddressId() string {
 	if x != nil {
-		return x.Status
+		return x.AddressId
 	}
 	return ""
 }
 
-func (x *OrderData) GetTotalPrice() float64 {
+type CheckoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Order         *OrderData             `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	CouponError   *CouponErrorData       `protobuf:"bytes,4,opt,name=coupon_error,json=couponError,proto3" json:"coupon_error,omitempty"` // Set when the cart's coupon was refused
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckoutResponse) Reset() {
+	*x = CheckoutResponse{}
+	mi := &file_proto_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckoutResponse) ProtoMessage() {}
+
+func (x *CheckoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[11]
 	if x != nil {
-		return x.TotalPrice
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *OrderData) GetShippingAddress() string {
+// Deprecated: Use CheckoutResponse.ProtoReflect.Descriptor instead.
+func (*CheckoutResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CheckoutResponse) GetSuccess() bool {
 	if x != nil {
-		return x.ShippingAddress
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *OrderData) GetPaymentMethod() string {
+func (x *CheckoutResponse) GetMessage() string {
 	if x != nil {
-		return x.PaymentMethod
+		return x.Message
 	}
 	return ""
 }
 
-func (x *OrderData) GetCreatedAt() string {
+func (x *CheckoutResponse) GetOrder() *OrderData {
 	if x != nil {
-		return x.CreatedAt
+		return x.Order
 	}
-	return ""
+	return nil
 }
 
-func (x *OrderData) GetUpdatedAt() string {
+func (x *CheckoutResponse) GetCouponError() *CouponErrorData {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CouponError
 	}
-	return ""
+	return nil
 }
 
-type OrderItemData struct {
+// Get Order History
+type GetOrderHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	ProductName   string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Subtotal      float64                `protobuf:"fixed64,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItemData) Reset() {
-	*x = OrderItemData{}
-	mi := &file_proto_order_proto_msgTypes[11]
+func (x *GetOrderHistoryRequest) Reset() {
+	*x = GetOrderHistoryRequest{}
+	mi := &file_proto_order_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItemData) String() string {
+func (x *GetOrderHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItemData) ProtoMessage() {}
+func (*GetOrderHistoryRequest) ProtoMessage() {}
 
-func (x *OrderItemData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[11]
+func (x *GetOrderHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,78 +822,100 @@ func (x *OrderItemData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItemData.ProtoReflect.Descriptor instead.
-func (*OrderItemData) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetOrderHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *OrderItemData) GetId() string {
+func (x *GetOrderHistoryRequest) GetOrderId() string {
 	if x != nil {
-		return x.Id
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *OrderItemData) GetProductId() string {
-	if x != nil {
-		return x.ProductId
-	}
-	return ""
+type GetOrderHistoryResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Success       bool                    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	History       []*OrderStatusEventData `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItemData) GetProductName() string {
+func (x *GetOrderHistoryResponse) Reset() {
+	*x = GetOrderHistoryResponse{}
+	mi := &file_proto_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[13]
 	if x != nil {
-		return x.ProductName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *OrderItemData) GetQuantity() int32 {
+// Deprecated: Use GetOrderHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderHistoryResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Quantity
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *OrderItemData) GetPrice() float64 {
+func (x *GetOrderHistoryResponse) GetMessage() string {
 	if x != nil {
-		return x.Price
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderItemData) GetSubtotal() float64 {
+func (x *GetOrderHistoryResponse) GetHistory() []*OrderStatusEventData {
 	if x != nil {
-		return x.Subtotal
+		return x.History
 	}
-	return 0
+	return nil
 }
 
-type OrderItemInput struct {
+type AnonymizeUserOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItemInput) Reset() {
-	*x = OrderItemInput{}
-	mi := &file_proto_order_proto_msgTypes[12]
+func (x *AnonymizeUserOrdersRequest) Reset() {
+	*x = AnonymizeUserOrdersRequest{}
+	mi := &file_proto_order_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItemInput) String() string {
+func (x *AnonymizeUserOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItemInput) ProtoMessage() {}
+func (*AnonymizeUserOrdersRequest) ProtoMessage() {}
 
-func (x *OrderItemInput) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_order_proto_msgTypes[12]
+func (x *AnonymizeUserOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,53 +926,1407 @@ func (x *OrderItemInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItemInput.ProtoReflect.Descriptor instead.
-func (*OrderItemInput) Descriptor() ([]byte, []int) {
-	return file_proto_order_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AnonymizeUserOrdersRequest.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *OrderItemInput) GetProductId() string {
+func (x *AnonymizeUserOrdersRequest) GetUserId() string {
 	if x != nil {
-		return x.ProductId
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *OrderItemInput) GetProductName() string {
+type AnonymizeUserOrdersResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	OrdersAnonymized int32                  `protobuf:"varint,3,opt,name=orders_anonymized,json=ordersAnonymized,proto3" json:"orders_anonymized,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserOrdersResponse) Reset() {
+	*x = AnonymizeUserOrdersResponse{}
+	mi := &file_proto_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserOrdersResponse) ProtoMessage() {}
+
+func (x *AnonymizeUserOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[15]
 	if x != nil {
-		return x.ProductName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserOrdersResponse.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AnonymizeUserOrdersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AnonymizeUserOrdersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *OrderItemInput) GetQuantity() int32 {
+func (x *AnonymizeUserOrdersResponse) GetOrdersAnonymized() int32 {
 	if x != nil {
-		return x.Quantity
+		return x.OrdersAnonymized
 	}
 	return 0
 }
 
-func (x *OrderItemInput) GetPrice() float64 {
+// Data Models
+type OrderData struct {
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	Id              string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          string                  `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*OrderItemData        `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	Status          string                  `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TotalPrice      float64                 `protobuf:"fixed64,5,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	ShippingAddress string                  `protobuf:"bytes,6,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	PaymentMethod   string                  `protobuf:"bytes,7,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	CreatedAt       string                  `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                  `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	History         []*OrderStatusEventData `protobuf:"bytes,10,rep,name=history,proto3" json:"history,omitempty"`
+	AddressId       string                  `protobuf:"bytes,11,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	RecipientName   string                  `protobuf:"bytes,12,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	RecipientPhone  string                  `protobuf:"bytes,13,opt,name=recipient_phone,json=recipientPhone,proto3" json:"recipient_phone,omitempty"`
+	AnonymizedAt    string                  `protobuf:"bytes,14,opt,name=anonymized_at,json=anonymizedAt,proto3" json:"anonymized_at,omitempty"`
+	Subtotal        float64                 `protobuf:"fixed64,15,opt,name=subtotal,proto3" json:"subtotal,omitempty"`                          // Sum of the items
+	Discount        float64                 `protobuf:"fixed64,16,opt,name=discount,proto3" json:"discount,omitempty"`                          // Coupon discount on the items
+	ShippingFee     float64                 `protobuf:"fixed64,17,opt,name=shipping_fee,json=shippingFee,proto3" json:"shipping_fee,omitempty"` // Zero with a free shipping coupon
+	CouponCode      string                  `protobuf:"bytes,18,opt,name=coupon_code,json=couponCode,proto3" json:"coupon_code,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OrderData) Reset() {
+	*x = OrderData{}
+	mi := &file_proto_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderData) ProtoMessage() {}
+
+func (x *OrderData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[16]
 	if x != nil {
-		return x.Price
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-var File_proto_order_proto protoreflect.FileDescriptor
+// Deprecated: Use OrderData.ProtoReflect.Descriptor instead.
+func (*OrderData) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{16}
+}
 
-const file_proto_order_proto_rawDesc = "" +
-	"\n" +
-	"\x11proto/order.proto\x12\x05order\"\xac\x01\n" +
-	"\x12CreateOrderRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12+\n" +
-	"\x05items\x18\x02 \x03(\v2\x15.order.OrderItemInputR\x05items\x12)\n" +
-	"\x10shipping_address\x18\x03 \x01(\tR\x0fshippingAddress\x12%\n" +
-	"\x0epayment_method\x18\x04 \x01(\tR\rpaymentMethod\"q\n" +
-	"\x13CreateOrderResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+func (x *OrderData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderData) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrderData) GetItems() []*OrderItemData {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderData) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderData) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *OrderData) GetShippingAddress() string {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return ""
+}
+
+func (x *OrderData) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *OrderData) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *OrderData) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *OrderData) GetHistory() []*OrderStatusEventData {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *OrderData) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+func (x *OrderData) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *OrderData) GetRecipientPhone() string {
+	if x != nil {
+		return x.RecipientPhone
+	}
+	return ""
+}
+
+func (x *OrderData) GetAnonymizedAt() string {
+	if x != nil {
+		return x.AnonymizedAt
+	}
+	return ""
+}
+
+func (x *OrderData) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *OrderData) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *OrderData) GetShippingFee() float64 {
+	if x != nil {
+		return x.ShippingFee
+	}
+	return 0
+}
+
+func (x *OrderData) GetCouponCode() string {
+	if x != nil {
+		return x.CouponCode
+	}
+	return ""
+}
+
+type OrderItemData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Subtotal      float64                `protobuf:"fixed64,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemData) Reset() {
+	*x = OrderItemData{}
+	mi := &file_proto_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemData) ProtoMessage() {}
+
+func (x *OrderItemData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemData.ProtoReflect.Descriptor instead.
+func (*OrderItemData) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *OrderItemData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderItemData) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItemData) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *OrderItemData) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItemData) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *OrderItemData) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+type OrderStatusEventData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusEventData) Reset() {
+	*x = OrderStatusEventData{}
+	mi := &file_proto_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusEventData) ProtoMessage() {}
+
+func (x *OrderStatusEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusEventData.ProtoReflect.Descriptor instead.
+func (*OrderStatusEventData) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OrderStatusEventData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OrderStatusEventData) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatusEventData) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusEventData) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusEventData) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderStatusEventData) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusEventData) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// product_name and price are ignored by the server; both are resolved from
+// product service when the order is created.
+type OrderItemInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemInput) Reset() {
+	*x = OrderItemInput{}
+	mi := &file_proto_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemInput) ProtoMessage() {}
+
+func (x *OrderItemInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemInput.ProtoReflect.Descriptor instead.
+func (*OrderItemInput) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OrderItemInput) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItemInput) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *OrderItemInput) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItemInput) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+// Terms of a coupon. Zero limits and min_basket mean none; empty categories
+// and brands make every product eligible. Times are RFC 3339 and optional.
+type CouponSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                                // percentage, fixed or free_shipping
+	Value         float64                `protobuf:"fixed64,4,opt,name=value,proto3" json:"value,omitempty"`                            // Percent or amount off; unused for free_shipping
+	MinBasket     float64                `protobuf:"fixed64,5,opt,name=min_basket,json=minBasket,proto3" json:"min_basket,omitempty"`   // Minimum subtotal of the whole basket
+	UsageLimit    int32                  `protobuf:"varint,6,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"` // Redemptions across all users
+	PerUserLimit  int32                  `protobuf:"varint,7,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"`
+	StartsAt      string                 `protobuf:"bytes,8,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Categories    []string               `protobuf:"bytes,10,rep,name=categories,proto3" json:"categories,omitempty"`
+	Brands        []string               `protobuf:"bytes,11,rep,name=brands,proto3" json:"brands,omitempty"`
+	Active        bool                   `protobuf:"varint,12,opt,name=active,proto3" json:"active,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CouponSpec) Reset() {
+	*x = CouponSpec{}
+	mi := &file_proto_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CouponSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CouponSpec) ProtoMessage() {}
+
+func (x *CouponSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CouponSpec.ProtoReflect.Descriptor instead.
+func (*CouponSpec) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CouponSpec) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CouponSpec) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CouponSpec) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CouponSpec) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CouponSpec) GetMinBasket() float64 {
+	if x != nil {
+		return x.MinBasket
+	}
+	return 0
+}
+
+func (x *CouponSpec) GetUsageLimit() int32 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *CouponSpec) GetPerUserLimit() int32 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
+func (x *CouponSpec) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *CouponSpec) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *CouponSpec) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *CouponSpec) GetBrands() []string {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *CouponSpec) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+// Create Coupon
+type CreateCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Coupon        *CouponSpec            `protobuf:"bytes,1,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCouponRequest) Reset() {
+	*x = CreateCouponRequest{}
+	mi := &file_proto_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCouponRequest) ProtoMessage() {}
+
+func (x *CreateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCouponRequest.ProtoReflect.Descriptor instead.
+func (*CreateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateCouponRequest) GetCoupon() *CouponSpec {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// Update Coupon replaces the terms of a coupon; its code cannot change
+type UpdateCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Coupon        *CouponSpec            `protobuf:"bytes,2,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCouponRequest) Reset() {
+	*x = UpdateCouponRequest{}
+	mi := &file_proto_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCouponRequest) ProtoMessage() {}
+
+func (x *UpdateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCouponRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateCouponRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCouponRequest) GetCoupon() *CouponSpec {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// Get Coupon
+type GetCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCouponRequest) Reset() {
+	*x = GetCouponRequest{}
+	mi := &file_proto_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCouponRequest) ProtoMessage() {}
+
+func (x *GetCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCouponRequest.ProtoReflect.Descriptor instead.
+func (*GetCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetCouponRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Coupon        *CouponData            `protobuf:"bytes,3,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CouponResponse) Reset() {
+	*x = CouponResponse{}
+	mi := &file_proto_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CouponResponse) ProtoMessage() {}
+
+func (x *CouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CouponResponse.ProtoReflect.Descriptor instead.
+func (*CouponResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CouponResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CouponResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CouponResponse) GetCoupon() *CouponData {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+// List Coupons
+type ListCouponsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouponsRequest) Reset() {
+	*x = ListCouponsRequest{}
+	mi := &file_proto_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouponsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouponsRequest) ProtoMessage() {}
+
+func (x *ListCouponsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouponsRequest.ProtoReflect.Descriptor instead.
+func (*ListCouponsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListCouponsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCouponsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListCouponsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Coupons       []*CouponData          `protobuf:"bytes,3,rep,name=coupons,proto3" json:"coupons,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouponsResponse) Reset() {
+	*x = ListCouponsResponse{}
+	mi := &file_proto_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouponsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouponsResponse) ProtoMessage() {}
+
+func (x *ListCouponsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouponsResponse.ProtoReflect.Descriptor instead.
+func (*ListCouponsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListCouponsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListCouponsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListCouponsResponse) GetCoupons() []*CouponData {
+	if x != nil {
+		return x.Coupons
+	}
+	return nil
+}
+
+func (x *ListCouponsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Validate Coupon works out what a coupon is worth on a basket, without
+// redeeming it
+type ValidateCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items         []*OrderItemInput      `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCouponRequest) Reset() {
+	*x = ValidateCouponRequest{}
+	mi := &file_proto_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCouponRequest) ProtoMessage() {}
+
+func (x *ValidateCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCouponRequest.ProtoReflect.Descriptor instead.
+func (*ValidateCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ValidateCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidateCouponRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ValidateCouponRequest) GetItems() []*OrderItemInput {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ValidateCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Quote         *CouponQuoteData       `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+	CouponError   *CouponErrorData       `protobuf:"bytes,4,opt,name=coupon_error,json=couponError,proto3" json:"coupon_error,omitempty"` // Set when the coupon does not apply
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateCouponResponse) Reset() {
+	*x = ValidateCouponResponse{}
+	mi := &file_proto_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateCouponResponse) ProtoMessage() {}
+
+func (x *ValidateCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateCouponResponse.ProtoReflect.Descriptor instead.
+func (*ValidateCouponResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ValidateCouponResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ValidateCouponResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateCouponResponse) GetQuote() *CouponQuoteData {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+func (x *ValidateCouponResponse) GetCouponError() *CouponErrorData {
+	if x != nil {
+		return x.CouponError
+	}
+	return nil
+}
+
+// What a coupon takes off a basket
+type CouponQuoteData struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Code             string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Type             string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Discount         float64                `protobuf:"fixed64,3,opt,name=discount,proto3" json:"discount,omitempty"` // Off the items
+	FreeShipping     bool                   `protobuf:"varint,4,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"`
+	EligibleSubtotal float64                `protobuf:"fixed64,5,opt,name=eligible_subtotal,json=eligibleSubtotal,proto3" json:"eligible_subtotal,omitempty"` // Subtotal of the items the coupon applies to
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CouponQuoteData) Reset() {
+	*x = CouponQuoteData{}
+	mi := &file_proto_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CouponQuoteData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CouponQuoteData) ProtoMessage() {}
+
+func (x *CouponQuoteData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CouponQuoteData.ProtoReflect.Descriptor instead.
+func (*CouponQuoteData) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CouponQuoteData) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CouponQuoteData) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CouponQuoteData) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *CouponQuoteData) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *CouponQuoteData) GetEligibleSubtotal() float64 {
+	if x != nil {
+		return x.EligibleSubtotal
+	}
+	return 0
+}
+
+// Why a coupon was refused
+type CouponErrorData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                              // not_found, inactive, not_started, expired, usage_limit_reached, user_limit_reached, min_basket_not_met or not_applicable
+	MinBasket     float64                `protobuf:"fixed64,2,opt,name=min_basket,json=minBasket,proto3" json:"min_basket,omitempty"` // Set for min_basket_not_met
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CouponErrorData) Reset() {
+	*x = CouponErrorData{}
+	mi := &file_proto_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CouponErrorData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CouponErrorData) ProtoMessage() {}
+
+func (x *CouponErrorData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CouponErrorData.ProtoReflect.Descriptor instead.
+func (*CouponErrorData) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CouponErrorData) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CouponErrorData) GetMinBasket() float64 {
+	if x != nil {
+		return x.MinBasket
+	}
+	return 0
+}
+
+// Coupon Data
+type CouponData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Value         float64                `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	MinBasket     float64                `protobuf:"fixed64,6,opt,name=min_basket,json=minBasket,proto3" json:"min_basket,omitempty"`
+	UsageLimit    int32                  `protobuf:"varint,7,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	PerUserLimit  int32                  `protobuf:"varint,8,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"`
+	TimesRedeemed int32                  `protobuf:"varint,9,opt,name=times_redeemed,json=timesRedeemed,proto3" json:"times_redeemed,omitempty"`
+	StartsAt      string                 `protobuf:"bytes,10,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,11,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Categories    []string               `protobuf:"bytes,12,rep,name=categories,proto3" json:"categories,omitempty"`
+	Brands        []string               `protobuf:"bytes,13,rep,name=brands,proto3" json:"brands,omitempty"`
+	Active        bool                   `protobuf:"varint,14,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CouponData) Reset() {
+	*x = CouponData{}
+	mi := &file_proto_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CouponData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CouponData) ProtoMessage() {}
+
+func (x *CouponData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CouponData.ProtoReflect.Descriptor instead.
+func (*CouponData) Descriptor() ([]byte, []int) {
+	return file_proto_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CouponData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CouponData) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CouponData) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CouponData) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CouponData) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *CouponData) GetMinBasket() float64 {
+	if x != nil {
+		return x.MinBasket
+	}
+	return 0
+}
+
+func (x *CouponData) GetUsageLimit() int32 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *CouponData) GetPerUserLimit() int32 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
+func (x *CouponData) GetTimesRedeemed() int32 {
+	if x != nil {
+		return x.TimesRedeemed
+	}
+	return 0
+}
+
+func (x *CouponData) GetStartsAt() string {
+	if x != nil {
+		return x.StartsAt
+	}
+	return ""
+}
+
+func (x *CouponData) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *CouponData) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *CouponData) GetBrands() []string {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *CouponData) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *CouponData) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *CouponData) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+var File_proto_order_proto protoreflect.FileDescriptor
+
+const file_proto_order_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/order.proto\x12\x05order\"\xec\x01\n" +
+	"\x12CreateOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12+\n" +
+	"\x05items\x18\x02 \x03(\v2\x15.order.OrderItemInputR\x05items\x12)\n" +
+	"\x10shipping_address\x18\x03 \x01(\tR\x0fshippingAddress\x12%\n" +
+	"\x0epayment_method\x18\x04 \x01(\tR\rpaymentMethod\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x05 \x01(\tR\taddressId\x12\x1f\n" +
+	"\vcoupon_code\x18\x06 \x01(\tR\n" +
+	"couponCode\"\xac\x01\n" +
+	"\x13CreateOrderResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
-	"\x05order\x18\x03 \x01(\v2\x10.order.OrderDataR\x05order\",\n" +
+	"\x05order\x18\x03 \x01(\v2\x10.order.OrderDataR\x05order\x129\n" +
+	"\fcoupon_error\x18\x04 \x01(\v2\x16.order.CouponErrorDataR\vcouponError\",\n" +
 	"\x0fGetOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\"n\n" +
 	"\x10GetOrderResponse\x12\x18\n" +
@@ -891,20 +2341,46 @@ const file_proto_order_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
 	"\x06orders\x18\x03 \x03(\v2\x10.order.OrderDataR\x06orders\x12\x14\n" +
-	"\x05total\x18\x04 \x01(\x05R\x05total\"M\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"{\n" +
 	"\x18UpdateOrderStatusRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"w\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"w\n" +
 	"\x19UpdateOrderStatusResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
-	"\x05order\x18\x03 \x01(\v2\x10.order.OrderDataR\x05order\"H\n" +
+	"\x05order\x18\x03 \x01(\v2\x10.order.OrderDataR\x05order\"`\n" +
 	"\x12CancelOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"I\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"I\n" +
 	"\x13CancelOrderResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xa9\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x9b\x01\n" +
+	"\x0fCheckoutRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x10shipping_address\x18\x02 \x01(\tR\x0fshippingAddress\x12%\n" +
+	"\x0epayment_method\x18\x03 \x01(\tR\rpaymentMethod\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x04 \x01(\tR\taddressId\"\xa9\x01\n" +
+	"\x10CheckoutResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12&\n" +
+	"\x05order\x18\x03 \x01(\v2\x10.order.OrderDataR\x05order\x129\n" +
+	"\fcoupon_error\x18\x04 \x01(\v2\x16.order.CouponErrorDataR\vcouponError\"3\n" +
+	"\x16GetOrderHistoryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x84\x01\n" +
+	"\x17GetOrderHistoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\ahistory\x18\x03 \x03(\v2\x1b.order.OrderStatusEventDataR\ahistory\"5\n" +
+	"\x1aAnonymizeUserOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"~\n" +
+	"\x1bAnonymizeUserOrdersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x11orders_anonymized\x18\x03 \x01(\x05R\x10ordersAnonymized\"\xf0\x04\n" +
 	"\tOrderData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12*\n" +
@@ -917,7 +2393,19 @@ const file_proto_order_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\tR\tupdatedAt\"\xaf\x01\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x125\n" +
+	"\ahistory\x18\n" +
+	" \x03(\v2\x1b.order.OrderStatusEventDataR\ahistory\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\v \x01(\tR\taddressId\x12%\n" +
+	"\x0erecipient_name\x18\f \x01(\tR\rrecipientName\x12'\n" +
+	"\x0frecipient_phone\x18\r \x01(\tR\x0erecipientPhone\x12#\n" +
+	"\ranonymized_at\x18\x0e \x01(\tR\fanonymizedAt\x12\x1a\n" +
+	"\bsubtotal\x18\x0f \x01(\x01R\bsubtotal\x12\x1a\n" +
+	"\bdiscount\x18\x10 \x01(\x01R\bdiscount\x12!\n" +
+	"\fshipping_fee\x18\x11 \x01(\x01R\vshippingFee\x12\x1f\n" +
+	"\vcoupon_code\x18\x12 \x01(\tR\n" +
+	"couponCode\"\xaf\x01\n" +
 	"\rOrderItemData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -925,20 +2413,123 @@ const file_proto_order_proto_rawDesc = "" +
 	"\fproduct_name\x18\x03 \x01(\tR\vproductName\x12\x1a\n" +
 	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
 	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bsubtotal\x18\x06 \x01(\x01R\bsubtotal\"\x84\x01\n" +
+	"\bsubtotal\x18\x06 \x01(\x01R\bsubtotal\"\xcc\x01\n" +
+	"\x14OrderStatusEventData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"\x84\x01\n" +
 	"\x0eOrderItemInput\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
 	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x1a\n" +
 	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price2\xf2\x02\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\"\xde\x02\n" +
+	"\n" +
+	"CouponSpec\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x01R\x05value\x12\x1d\n" +
+	"\n" +
+	"min_basket\x18\x05 \x01(\x01R\tminBasket\x12\x1f\n" +
+	"\vusage_limit\x18\x06 \x01(\x05R\n" +
+	"usageLimit\x12$\n" +
+	"\x0eper_user_limit\x18\a \x01(\x05R\fperUserLimit\x12\x1b\n" +
+	"\tstarts_at\x18\b \x01(\tR\bstartsAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\tR\texpiresAt\x12\x1e\n" +
+	"\n" +
+	"categories\x18\n" +
+	" \x03(\tR\n" +
+	"categories\x12\x16\n" +
+	"\x06brands\x18\v \x03(\tR\x06brands\x12\x16\n" +
+	"\x06active\x18\f \x01(\bR\x06active\"@\n" +
+	"\x13CreateCouponRequest\x12)\n" +
+	"\x06coupon\x18\x01 \x01(\v2\x11.order.CouponSpecR\x06coupon\"P\n" +
+	"\x13UpdateCouponRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x06coupon\x18\x02 \x01(\v2\x11.order.CouponSpecR\x06coupon\"\"\n" +
+	"\x10GetCouponRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
+	"\x0eCouponResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x06coupon\x18\x03 \x01(\v2\x11.order.CouponDataR\x06coupon\"E\n" +
+	"\x12ListCouponsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x8c\x01\n" +
+	"\x13ListCouponsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\acoupons\x18\x03 \x03(\v2\x11.order.CouponDataR\acoupons\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"q\n" +
+	"\x15ValidateCouponRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12+\n" +
+	"\x05items\x18\x03 \x03(\v2\x15.order.OrderItemInputR\x05items\"\xb5\x01\n" +
+	"\x16ValidateCouponResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x05quote\x18\x03 \x01(\v2\x16.order.CouponQuoteDataR\x05quote\x129\n" +
+	"\fcoupon_error\x18\x04 \x01(\v2\x16.order.CouponErrorDataR\vcouponError\"\xa7\x01\n" +
+	"\x0fCouponQuoteData\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bdiscount\x18\x03 \x01(\x01R\bdiscount\x12#\n" +
+	"\rfree_shipping\x18\x04 \x01(\bR\ffreeShipping\x12+\n" +
+	"\x11eligible_subtotal\x18\x05 \x01(\x01R\x10eligibleSubtotal\"D\n" +
+	"\x0fCouponErrorData\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"min_basket\x18\x02 \x01(\x01R\tminBasket\"\xd3\x03\n" +
+	"\n" +
+	"CouponData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x01R\x05value\x12\x1d\n" +
+	"\n" +
+	"min_basket\x18\x06 \x01(\x01R\tminBasket\x12\x1f\n" +
+	"\vusage_limit\x18\a \x01(\x05R\n" +
+	"usageLimit\x12$\n" +
+	"\x0eper_user_limit\x18\b \x01(\x05R\fperUserLimit\x12%\n" +
+	"\x0etimes_redeemed\x18\t \x01(\x05R\rtimesRedeemed\x12\x1b\n" +
+	"\tstarts_at\x18\n" +
+	" \x01(\tR\bstartsAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\v \x01(\tR\texpiresAt\x12\x1e\n" +
+	"\n" +
+	"categories\x18\f \x03(\tR\n" +
+	"categories\x12\x16\n" +
+	"\x06brands\x18\r \x03(\tR\x06brands\x12\x16\n" +
+	"\x06active\x18\x0e \x01(\bR\x06active\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\tR\tupdatedAt2\xdf\x04\n" +
 	"\fOrderService\x12D\n" +
 	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x1a.order.CreateOrderResponse\x12;\n" +
 	"\bGetOrder\x12\x16.order.GetOrderRequest\x1a\x17.order.GetOrderResponse\x12A\n" +
 	"\n" +
 	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponse\x12V\n" +
 	"\x11UpdateOrderStatus\x12\x1f.order.UpdateOrderStatusRequest\x1a .order.UpdateOrderStatusResponse\x12D\n" +
-	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x1a.order.CancelOrderResponseB2Z0jumia-clone-backend/services/order-service/protob\x06proto3"
+	"\vCancelOrder\x12\x19.order.CancelOrderRequest\x1a\x1a.order.CancelOrderResponse\x12;\n" +
+	"\bCheckout\x12\x16.order.CheckoutRequest\x1a\x17.order.CheckoutResponse\x12P\n" +
+	"\x0fGetOrderHistory\x12\x1d.order.GetOrderHistoryRequest\x1a\x1e.order.GetOrderHistoryResponse\x12\\\n" +
+	"\x13AnonymizeUserOrders\x12!.order.AnonymizeUserOrdersRequest\x1a\".order.AnonymizeUserOrdersResponse2\xe7\x02\n" +
+	"\rCouponService\x12A\n" +
+	"\fCreateCoupon\x12\x1a.order.CreateCouponRequest\x1a\x15.order.CouponResponse\x12A\n" +
+	"\fUpdateCoupon\x12\x1a.order.UpdateCouponRequest\x1a\x15.order.CouponResponse\x12;\n" +
+	"\tGetCoupon\x12\x17.order.GetCouponRequest\x1a\x15.order.CouponResponse\x12D\n" +
+	"\vListCoupons\x12\x19.order.ListCouponsRequest\x1a\x1a.order.ListCouponsResponse\x12M\n" +
+	"\x0eValidateCoupon\x12\x1c.order.ValidateCouponRequest\x1a\x1d.order.ValidateCouponResponseB2Z0jumia-clone-backend/services/order-service/protob\x06proto3"
 
 var (
 	file_proto_order_proto_rawDescOnce sync.Once
@@ -952,44 +2543,91 @@ func file_proto_order_proto_rawDescGZIP() []byte {
 	return file_proto_order_proto_rawDescData
 }
 
-var file_proto_order_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_order_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_proto_order_proto_goTypes = []any{
-	(*CreateOrderRequest)(nil),        // 0: order.CreateOrderRequest
-	(*CreateOrderResponse)(nil),       // 1: order.CreateOrderResponse
-	(*GetOrderRequest)(nil),           // 2: order.GetOrderRequest
-	(*GetOrderResponse)(nil),          // 3: order.GetOrderResponse
-	(*ListOrdersRequest)(nil),         // 4: order.ListOrdersRequest
-	(*ListOrdersResponse)(nil),        // 5: order.ListOrdersResponse
-	(*UpdateOrderStatusRequest)(nil),  // 6: order.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil), // 7: order.UpdateOrderStatusResponse
-	(*CancelOrderRequest)(nil),        // 8: order.CancelOrderRequest
-	(*CancelOrderResponse)(nil),       // 9: order.CancelOrderResponse
-	(*OrderData)(nil),                 // 10: order.OrderData
-	(*OrderItemData)(nil),             // 11: order.OrderItemData
-	(*OrderItemInput)(nil),            // 12: order.OrderItemInput
+	(*CreateOrderRequest)(nil),          // 0: order.CreateOrderRequest
+	(*CreateOrderResponse)(nil),         // 1: order.CreateOrderResponse
+	(*GetOrderRequest)(nil),             // 2: order.GetOrderRequest
+	(*GetOrderResponse)(nil),            // 3: order.GetOrderResponse
+	(*ListOrdersRequest)(nil),           // 4: order.ListOrdersRequest
+	(*ListOrdersResponse)(nil),          // 5: order.ListOrdersResponse
+	(*UpdateOrderStatusRequest)(nil),    // 6: order.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),   // 7: order.UpdateOrderStatusResponse
+	(*CancelOrderRequest)(nil),          // 8: order.CancelOrderRequest
+	(*CancelOrderResponse)(nil),         // 9: order.CancelOrderResponse
+	(*CheckoutRequest)(nil),             // 10: order.CheckoutRequest
+	(*CheckoutResponse)(nil),            // 11: order.CheckoutResponse
+	(*GetOrderHistoryRequest)(nil),      // 12: order.GetOrderHistoryRequest
+	(*GetOrderHistoryResponse)(nil),     // 13: order.GetOrderHistoryResponse
+	(*AnonymizeUserOrdersRequest)(nil),  // 14: order.AnonymizeUserOrdersRequest
+	(*AnonymizeUserOrdersResponse)(nil), // 15: order.AnonymizeUserOrdersResponse
+	(*OrderData)(nil),                   // 16: order.OrderData
+	(*OrderItemData)(nil),               // 17: order.OrderItemData
+	(*OrderStatusEventData)(nil),        // 18: order.OrderStatusEventData
+	(*OrderItemInput)(nil),              // 19: order.OrderItemInput
+	(*CouponSpec)(nil),                  // 20: order.CouponSpec
+	(*CreateCouponRequest)(nil),         // 21: order.CreateCouponRequest
+	(*UpdateCouponRequest)(nil),         // 22: order.UpdateCouponRequest
+	(*GetCouponRequest)(nil),            // 23: order.GetCouponRequest
+	(*CouponResponse)(nil),              // 24: order.CouponResponse
+	(*ListCouponsRequest)(nil),          // 25: order.ListCouponsRequest
+	(*ListCouponsResponse)(nil),         // 26: order.ListCouponsResponse
+	(*ValidateCouponRequest)(nil),       // 27: order.ValidateCouponRequest
+	(*ValidateCouponResponse)(nil),      // 28: order.ValidateCouponResponse
+	(*CouponQuoteData)(nil),             // 29: order.CouponQuoteData
+	(*CouponErrorData)(nil),             // 30: order.CouponErrorData
+	(*CouponData)(nil),                  // 31: order.CouponData
 }
 var file_proto_order_proto_depIdxs = []int32{
-	12, // 0: order.CreateOrderRequest.items:type_name -> order.OrderItemInput
-	10, // 1: order.CreateOrderResponse.order:type_name -> order.OrderData
-	10, // 2: order.GetOrderResponse.order:type_name -> order.OrderData
-	10, // 3: order.ListOrdersResponse.orders:type_name -> order.OrderData
-	10, // 4: order.UpdateOrderStatusResponse.order:type_name -> order.OrderData
-	11, // 5: order.OrderData.items:type_name -> order.OrderItemData
-	0,  // 6: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	2,  // 7: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
-	4,  // 8: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
-	6,  // 9: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
-	8,  // 10: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
-	1,  // 11: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
-	3,  // 12: order.OrderService.GetOrder:output_type -> order.GetOrderResponse
-	5,  // 13: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
-	7,  // 14: order.OrderService.UpdateOrderStatus:output_type -> order.UpdateOrderStatusResponse
-	9,  // 15: order.OrderService.CancelOrder:output_type -> order.CancelOrderResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	19, // 0: order.CreateOrderRequest.items:type_name -> order.OrderItemInput
+	16, // 1: order.CreateOrderResponse.order:type_name -> order.OrderData
+	30, // 2: order.CreateOrderResponse.coupon_error:type_name -> order.CouponErrorData
+	16, // 3: order.GetOrderResponse.order:type_name -> order.OrderData
+	16, // 4: order.ListOrdersResponse.orders:type_name -> order.OrderData
+	16, // 5: order.UpdateOrderStatusResponse.order:type_name -> order.OrderData
+	16, // 6: order.CheckoutResponse.order:type_name -> order.OrderData
+	30, // 7: order.CheckoutResponse.coupon_error:type_name -> order.CouponErrorData
+	18, // 8: order.GetOrderHistoryResponse.history:type_name -> order.OrderStatusEventData
+	17, // 9: order.OrderData.items:type_name -> order.OrderItemData
+	18, // 10: order.OrderData.history:type_name -> order.OrderStatusEventData
+	20, // 11: order.CreateCouponRequest.coupon:type_name -> order.CouponSpec
+	20, // 12: order.UpdateCouponRequest.coupon:type_name -> order.CouponSpec
+	31, // 13: order.CouponResponse.coupon:type_name -> order.CouponData
+	31, // 14: order.ListCouponsResponse.coupons:type_name -> order.CouponData
+	19, // 15: order.ValidateCouponRequest.items:type_name -> order.OrderItemInput
+	29, // 16: order.ValidateCouponResponse.quote:type_name -> order.CouponQuoteData
+	30, // 17: order.ValidateCouponResponse.coupon_error:type_name -> order.CouponErrorData
+	0,  // 18: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	2,  // 19: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
+	4,  // 20: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
+	6,  // 21: order.OrderService.UpdateOrderStatus:input_type -> order.UpdateOrderStatusRequest
+	8,  // 22: order.OrderService.CancelOrder:input_type -> order.CancelOrderRequest
+	10, // 23: order.OrderService.Checkout:input_type -> order.CheckoutRequest
+	12, // 24: order.OrderService.GetOrderHistory:input_type -> order.GetOrderHistoryRequest
+	14, // 25: order.OrderService.AnonymizeUserOrders:input_type -> order.AnonymizeUserOrdersRequest
+	21, // 26: order.CouponService.CreateCoupon:input_type -> order.CreateCouponRequest
+	22, // 27: order.CouponService.UpdateCoupon:input_type -> order.UpdateCouponRequest
+	23, // 28: order.CouponService.GetCoupon:input_type -> order.GetCouponRequest
+	25, // 29: order.CouponService.ListCoupons:input_type -> order.ListCouponsRequest
+	27, // 30: order.CouponService.ValidateCoupon:input_type -> order.ValidateCouponRequest
+	1,  // 31: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
+	3,  // 32: order.OrderService.GetOrder:output_type -> order.GetOrderResponse
+	5,  // 33: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
+	7,  // 34: order.OrderService.UpdateOrderStatus:output_type -> order.UpdateOrderStatusResponse
+	9,  // 35: order.OrderService.CancelOrder:output_type -> order.CancelOrderResponse
+	11, // 36: order.OrderService.Checkout:output_type -> order.CheckoutResponse
+	13, // 37: order.OrderService.GetOrderHistory:output_type -> order.GetOrderHistoryResponse
+	15, // 38: order.OrderService.AnonymizeUserOrders:output_type -> order.AnonymizeUserOrdersResponse
+	24, // 39: order.CouponService.CreateCoupon:output_type -> order.CouponResponse
+	24, // 40: order.CouponService.UpdateCoupon:output_type -> order.CouponResponse
+	24, // 41: order.CouponService.GetCoupon:output_type -> order.CouponResponse
+	26, // 42: order.CouponService.ListCoupons:output_type -> order.ListCouponsResponse
+	28, // 43: order.CouponService.ValidateCoupon:output_type -> order.ValidateCouponResponse
+	31, // [31:44] is the sub-list for method output_type
+	18, // [18:31] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_proto_order_proto_init() }
@@ -1003,9 +2641,9 @@ func file_proto_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_order_proto_rawDesc), len(file_proto_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   32,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_order_proto_goTypes,
 		DependencyIndexes: file_proto_order_proto_depIdxs,
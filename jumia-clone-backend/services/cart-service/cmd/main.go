@@ -3,13 +3,18 @@ package main
 import (
 	"log"
 	"net"
+	"os"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"jumia-clone-backend/services/cart-service/internal/client"
 	"jumia-clone-backend/services/cart-service/internal/handler"
 	"jumia-clone-backend/services/cart-service/internal/models"
+	"jumia-clone-backend/services/cart-service/internal/notifier"
 	"jumia-clone-backend/services/cart-service/internal/repository"
 	"jumia-clone-backend/services/cart-service/internal/service"
 	pb "jumia-clone-backend/services/cart-service/proto"
@@ -24,16 +29,79 @@ func main() {
 	}
 
 	// Auto-migrate the schema
-	if err := db.AutoMigrate(&models.Cart{}, &models.CartItem{}); err != nil {
+	if err := db.AutoMigrate(&models.Cart{}, &models.CartItem{}, &models.CartNudge{}, &models.Wishlist{}, &models.WishlistItem{}); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
 	log.Println("Database connected and migrated successfully")
 
+	// Guest cart tokens are signed so they cannot be forged. The development
+	// secret is public, so it is only used when APP_ENV=development says so.
+	cartTokenSecret := os.Getenv("CART_TOKEN_SECRET")
+	if cartTokenSecret == "" {
+		if os.Getenv("APP_ENV") != "development" {
+			log.Fatal("CART_TOKEN_SECRET is required")
+		}
+		log.Println("CART_TOKEN_SECRET is not set, using the development secret")
+		cartTokenSecret = "cart-token-secret-change-this-in-production"
+	}
+	guestCartTTL, err := time.ParseDuration(getEnv("GUEST_CART_TTL", "720h"))
+	if err != nil {
+		log.Fatalf("Invalid GUEST_CART_TTL: %v", err)
+	}
+
+	// Units of one product a cart may hold, unless the product sets its own max_per_order
+	maxPerProduct, err := strconv.Atoi(getEnv("MAX_QUANTITY_PER_PRODUCT", "10"))
+	if err != nil || maxPerProduct < 0 {
+		log.Fatalf("Invalid MAX_QUANTITY_PER_PRODUCT: %q", os.Getenv("MAX_QUANTITY_PER_PRODUCT"))
+	}
+
+	// Carts whose items are untouched this long are abandoned; 0 disables reminders
+	abandonedCartAfter, err := time.ParseDuration(getEnv("ABANDONED_CART_AFTER", "24h"))
+	if err != nil || abandonedCartAfter < 0 {
+		log.Fatalf("Invalid ABANDONED_CART_AFTER: %q", os.Getenv("ABANDONED_CART_AFTER"))
+	}
+	abandonedCartInterval, err := time.ParseDuration(getEnv("ABANDONED_CART_CHECK_INTERVAL", "15m"))
+	if err != nil || abandonedCartInterval <= 0 {
+		log.Fatalf("Invalid ABANDONED_CART_CHECK_INTERVAL: %q", os.Getenv("ABANDONED_CART_CHECK_INTERVAL"))
+	}
+
+	notify, err := notifier.NewLogNotifier(os.Getenv("NOTIFIER_LOG_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	// Connect to product service for authoritative prices and availability
+	productServiceAddr := getEnv("PRODUCT_SERVICE_ADDR", "localhost:50052")
+	productClient, err := client.NewProductClient(productServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to product service: %v", err)
+	}
+	defer productClient.Close()
+
+	// Connect to order service, which owns coupons
+	orderServiceAddr := getEnv("ORDER_SERVICE_ADDR", "localhost:50054")
+	couponClient, err := client.NewCouponClient(orderServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to order service: %v", err)
+	}
+	defer couponClient.Close()
+
 	// Initialize layers
 	cartRepo := repository.NewCartRepository(db)
-	cartService := service.NewCartService(cartRepo)
+	cartService := service.NewCartService(cartRepo, productClient, couponClient, service.NewCartTokenSigner(cartTokenSecret), guestCartTTL, maxPerProduct)
 	cartHandler := handler.NewCartHandler(cartService)
+	wishlistService := service.NewWishlistService(repository.NewWishlistRepository(db), cartRepo, cartService, productClient)
+	wishlistHandler := handler.NewWishlistHandler(wishlistService)
+
+	// Periodically purge guest carts that were abandoned
+	go cleanupGuestCarts(cartService, time.Hour)
+
+	// Periodically remind users of carts they left behind
+	if abandonedCartAfter > 0 {
+		abandonedCartService := service.NewAbandonedCartService(cartRepo, notify, abandonedCartAfter)
+		go notifyAbandonedCarts(abandonedCartService, abandonedCartInterval)
+	}
 
 	// Set up gRPC server
 	lis, err := net.Listen("tcp", ":50053")
@@ -43,9 +111,52 @@ func main() {
 
 	s := grpc.NewServer()
 	pb.RegisterCartServiceServer(s, cartHandler)
+	pb.RegisterWishlistServiceServer(s, wishlistHandler)
 
 	log.Println("Cart service is running on port 50053...")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
+
+// getEnv gets environment variable or returns default value
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// cleanupGuestCarts deletes stale guest carts on every tick
+func cleanupGuestCarts(cartService service.CartService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := cartService.CleanupGuestCarts()
+		if err != nil {
+			log.Printf("Failed to clean up guest carts: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Cleaned up %d guest carts", deleted)
+		}
+	}
+}
+
+// notifyAbandonedCarts notifies the owners of abandoned carts on every tick
+func notifyAbandonedCarts(abandonedCartService service.AbandonedCartService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		sent, err := abandonedCartService.NotifyAbandonedCarts()
+		if err != nil {
+			log.Printf("Failed to notify abandoned carts: %v", err)
+		}
+		if sent > 0 {
+			log.Printf("Sent %d abandoned cart reminders", sent)
+		}
+	}
+}
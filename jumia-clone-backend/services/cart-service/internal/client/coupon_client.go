@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "jumia-clone-backend/services/cart-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// CouponRejectedError is returned when order service refuses a coupon for a
+// basket. Code is one of the CouponErrorData codes of order service.
+type CouponRejectedError struct {
+	Code      string
+	Message   string
+	MinBasket float64
+}
+
+func (e *CouponRejectedError) Error() string {
+	return e.Message
+}
+
+// CouponItem is a cart line to price a coupon against
+type CouponItem struct {
+	ProductID string
+	Quantity  int
+}
+
+// CouponClient checks coupons with order service, which owns them
+type CouponClient interface {
+	ValidateCoupon(code, userID string, items []CouponItem) (*pb.CouponQuoteData, error)
+	Close() error
+}
+
+type couponClient struct {
+	conn   *grpc.ClientConn
+	client pb.CouponServiceClient
+}
+
+// NewCouponClient connects to order service at the given address
+func NewCouponClient(addr string) (CouponClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &couponClient{
+		conn:   conn,
+		client: pb.NewCouponServiceClient(conn),
+	}, nil
+}
+
+// ValidateCoupon returns what the coupon takes off a basket of the given
+// items, or a *CouponRejectedError if it does not apply. Any other error
+// means order service could not check the coupon.
+func (c *couponClient) ValidateCoupon(code, userID string, items []CouponItem) (*pb.CouponQuoteData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	orderItems := make([]*pb.OrderItemInput, 0, len(items))
+	for _, item := range items {
+		orderItems = append(orderItems, &pb.OrderItemInput{
+			ProductId: item.ProductID,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	resp, err := c.client.ValidateCoupon(ctx, &pb.ValidateCouponRequest{
+		Code:   code,
+		UserId: userID,
+		Items:  orderItems,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.CouponError != nil {
+		return nil, &CouponRejectedError{
+			Code:      resp.CouponError.Code,
+			Message:   resp.Message,
+			MinBasket: resp.CouponError.MinBasket,
+		}
+	}
+	if !resp.Success || resp.Quote == nil {
+		return nil, errors.New(resp.Message)
+	}
+
+	return resp.Quote, nil
+}
+
+func (c *couponClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "jumia-clone-backend/services/cart-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ErrProductNotFound is returned for products that do not exist or were deleted
+var ErrProductNotFound = errors.New("product not found")
+
+// ProductClient looks up authoritative product data in product service
+type ProductClient interface {
+	GetProduct(productID string) (*pb.ProductData, error)
+	Close() error
+}
+
+type productClient struct {
+	conn   *grpc.ClientConn
+	client pb.ProductServiceClient
+}
+
+// NewProductClient connects to product service at the given address
+func NewProductClient(addr string) (ProductClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &productClient{
+		conn:   conn,
+		client: pb.NewProductServiceClient(conn),
+	}, nil
+}
+
+// GetProduct returns the product if it exists and is active. Product
+// service answers unknown and deleted products alike with ErrProductNotFound;
+// any other error means product service could not be reached.
+func (c *productClient) GetProduct(productID string) (*pb.ProductData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetProduct(ctx, &pb.GetProductRequest{Id: productID})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success || resp.Product == nil {
+		return nil, ErrProductNotFound
+	}
+
+	return resp.Product, nil
+}
+
+func (c *productClient) Close() error {
+	return c.conn.Close()
+}
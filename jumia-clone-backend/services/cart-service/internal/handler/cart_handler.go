@@ -2,8 +2,10 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"jumia-clone-backend/services/cart-service/internal/client"
 	"jumia-clone-backend/services/cart-service/internal/models"
 	"jumia-clone-backend/services/cart-service/internal/service"
 	pb "jumia-clone-backend/services/cart-service/proto"
@@ -22,17 +24,15 @@ func NewCartHandler(cartService service.CartService) *CartServiceHandler {
 
 func (h *CartServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartRequest) (*pb.AddToCartResponse, error) {
 	cart, err := h.cartService.AddToCart(
-		req.UserId,
+		cartOwner(req.UserId, req.CartToken),
 		req.ProductId,
-		req.ProductName,
-		req.ImageUrl,
 		int(req.Quantity),
-		req.Price,
 	)
 	if err != nil {
 		return &pb.AddToCartResponse{
-			Success: false,
-			Message: err.Error(),
+			Success:       false,
+			Message:       err.Error(),
+			QuantityError: convertToQuantityErrorData(err),
 		}, nil
 	}
 
@@ -44,11 +44,12 @@ func (h *CartServiceHandler) AddToCart(ctx context.Context, req *pb.AddToCartReq
 }
 
 func (h *CartServiceHandler) UpdateCartItem(ctx context.Context, req *pb.UpdateCartItemRequest) (*pb.UpdateCartItemResponse, error) {
-	cart, err := h.cartService.UpdateCartItem(req.UserId, req.ProductId, int(req.Quantity))
+	cart, err := h.cartService.UpdateCartItem(cartOwner(req.UserId, req.CartToken), req.ProductId, int(req.Quantity))
 	if err != nil {
 		return &pb.UpdateCartItemResponse{
-			Success: false,
-			Message: err.Error(),
+			Success:       false,
+			Message:       err.Error(),
+			QuantityError: convertToQuantityErrorData(err),
 		}, nil
 	}
 
@@ -60,7 +61,7 @@ func (h *CartServiceHandler) UpdateCartItem(ctx context.Context, req *pb.UpdateC
 }
 
 func (h *CartServiceHandler) RemoveFromCart(ctx context.Context, req *pb.RemoveFromCartRequest) (*pb.RemoveFromCartResponse, error) {
-	cart, err := h.cartService.RemoveFromCart(req.UserId, req.ProductId)
+	cart, err := h.cartService.RemoveFromCart(cartOwner(req.UserId, req.CartToken), req.ProductId)
 	if err != nil {
 		return &pb.RemoveFromCartResponse{
 			Success: false,
@@ -76,7 +77,7 @@ func (h *CartServiceHandler) RemoveFromCart(ctx context.Context, req *pb.RemoveF
 }
 
 func (h *CartServiceHandler) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.GetCartResponse, error) {
-	cart, err := h.cartService.GetCart(req.UserId)
+	cart, err := h.cartService.GetCart(cartOwner(req.UserId, req.CartToken))
 	if err != nil {
 		return &pb.GetCartResponse{
 			Success: false,
@@ -92,7 +93,7 @@ func (h *CartServiceHandler) GetCart(ctx context.Context, req *pb.GetCartRequest
 }
 
 func (h *CartServiceHandler) ClearCart(ctx context.Context, req *pb.ClearCartRequest) (*pb.ClearCartResponse, error) {
-	err := h.cartService.ClearCart(req.UserId)
+	err := h.cartService.ClearCart(cartOwner(req.UserId, req.CartToken))
 	if err != nil {
 		return &pb.ClearCartResponse{
 			Success: false,
@@ -106,27 +107,158 @@ func (h *CartServiceHandler) ClearCart(ctx context.Context, req *pb.ClearCartReq
 	}, nil
 }
 
+// EraseUserCart deletes a user's cart for account erasure
+func (h *CartServiceHandler) EraseUserCart(ctx context.Context, req *pb.EraseUserCartRequest) (*pb.EraseUserCartResponse, error) {
+	if err := h.cartService.EraseUserCart(req.UserId); err != nil {
+		return &pb.EraseUserCartResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.EraseUserCartResponse{
+		Success: true,
+		Message: "Cart erased successfully",
+	}, nil
+}
+
+func (h *CartServiceHandler) CreateGuestCart(ctx context.Context, req *pb.CreateGuestCartRequest) (*pb.CreateGuestCartResponse, error) {
+	cart, token, err := h.cartService.CreateGuestCart()
+	if err != nil {
+		return &pb.CreateGuestCartResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.CreateGuestCartResponse{
+		Success:   true,
+		Message:   "Guest cart created successfully",
+		CartToken: token,
+		Cart:      convertToCartData(cart),
+	}, nil
+}
+
+func (h *CartServiceHandler) MergeCarts(ctx context.Context, req *pb.MergeCartsRequest) (*pb.MergeCartsResponse, error) {
+	cart, err := h.cartService.MergeCarts(req.UserId, req.CartToken)
+	if err != nil {
+		return &pb.MergeCartsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.MergeCartsResponse{
+		Success: true,
+		Message: "Carts merged successfully",
+		Cart:    convertToCartData(cart),
+	}, nil
+}
+
+func (h *CartServiceHandler) ApplyCoupon(ctx context.Context, req *pb.ApplyCouponRequest) (*pb.ApplyCouponResponse, error) {
+	cart, err := h.cartService.ApplyCoupon(service.CartOwner{UserID: req.UserId}, req.Code)
+	if err != nil {
+		resp := &pb.ApplyCouponResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+		var rejected *client.CouponRejectedError
+		if errors.As(err, &rejected) {
+			resp.CouponError = &pb.CouponErrorData{
+				Code:      rejected.Code,
+				MinBasket: rejected.MinBasket,
+			}
+		}
+		return resp, nil
+	}
+
+	return &pb.ApplyCouponResponse{
+		Success: true,
+		Message: "Coupon applied successfully",
+		Cart:    convertToCartData(cart),
+	}, nil
+}
+
+func (h *CartServiceHandler) RemoveCoupon(ctx context.Context, req *pb.RemoveCouponRequest) (*pb.RemoveCouponResponse, error) {
+	cart, err := h.cartService.RemoveCoupon(service.CartOwner{UserID: req.UserId})
+	if err != nil {
+		return &pb.RemoveCouponResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.RemoveCouponResponse{
+		Success: true,
+		Message: "Coupon removed successfully",
+		Cart:    convertToCartData(cart),
+	}, nil
+}
+
+func cartOwner(userID, cartToken string) service.CartOwner {
+	return service.CartOwner{UserID: userID, CartToken: cartToken}
+}
+
 func convertToCartData(cart *models.Cart) *pb.CartData {
 	items := make([]*pb.CartItemData, 0, len(cart.Items))
 	for _, item := range cart.Items {
 		items = append(items, &pb.CartItemData{
-			Id:          item.ID,
-			ProductId:   item.ProductID,
-			ProductName: item.ProductName,
-			ImageUrl:    item.ImageURL,
-			Quantity:    int32(item.Quantity),
-			Price:       item.Price,
-			Subtotal:    item.GetSubtotal(),
+			Id:           item.ID,
+			ProductId:    item.ProductID,
+			ProductName:  item.ProductName,
+			ImageUrl:     item.ImageURL,
+			Quantity:     int32(item.Quantity),
+			Price:        item.UnitPrice(),
+			Subtotal:     item.GetSubtotal(),
+			AddedPrice:   item.Price,
+			PriceChanged: item.PriceChanged(),
+			Unavailable:  item.Unavailable,
+			MaxQuantity:  int32(item.MaxQuantity),
 		})
 	}
 
+	// A guest cart's user_id is the guest ID from its cart token
+	userID := cart.UserID
+	if cart.IsGuest {
+		userID = ""
+	}
+
+	var coupon *pb.AppliedCouponData
+	if cart.Coupon != nil {
+		coupon = &pb.AppliedCouponData{
+			Code:         cart.Coupon.Code,
+			Type:         cart.Coupon.Type,
+			Discount:     cart.Coupon.Discount,
+			FreeShipping: cart.Coupon.FreeShipping,
+			Valid:        cart.Coupon.Valid,
+			Error:        cart.Coupon.Error,
+		}
+	}
+
 	return &pb.CartData{
-		Id:         cart.ID,
-		UserId:     cart.UserID,
-		Items:      items,
-		TotalPrice: cart.GetTotalPrice(),
-		TotalItems: int32(cart.GetTotalItems()),
-		CreatedAt:  cart.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:  cart.UpdatedAt.Format(time.RFC3339),
+		Id:                 cart.ID,
+		UserId:             userID,
+		Guest:              cart.IsGuest,
+		Items:              items,
+		TotalPrice:         cart.GetTotalPrice(),
+		TotalItems:         int32(cart.GetTotalItems()),
+		CreatedAt:          cart.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          cart.UpdatedAt.Format(time.RFC3339),
+		Coupon:             coupon,
+		Discount:           cart.GetDiscount(),
+		TotalAfterDiscount: models.RoundPrice(cart.GetTotalPrice() - cart.GetDiscount()),
+	}
+}
+
+// convertToQuantityErrorData describes a refused quantity, or returns nil for other errors
+func convertToQuantityErrorData(err error) *pb.QuantityErrorData {
+	var quantityErr *service.QuantityError
+	if !errors.As(err, &quantityErr) {
+		return nil
+	}
+	return &pb.QuantityErrorData{
+		Code:        quantityErr.Code,
+		ProductId:   quantityErr.ProductID,
+		MaxQuantity: int32(quantityErr.MaxQuantity),
 	}
 }
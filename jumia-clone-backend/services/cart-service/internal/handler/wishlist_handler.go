@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"jumia-clone-backend/services/cart-service/internal/models"
+	"jumia-clone-backend/services/cart-service/internal/repository"
+	"jumia-clone-backend/services/cart-service/internal/service"
+	pb "jumia-clone-backend/services/cart-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type WishlistServiceHandler struct {
+	pb.UnimplementedWishlistServiceServer
+	wishlistService service.WishlistService
+}
+
+func NewWishlistHandler(wishlistService service.WishlistService) *WishlistServiceHandler {
+	return &WishlistServiceHandler{
+		wishlistService: wishlistService,
+	}
+}
+
+func (h *WishlistServiceHandler) CreateWishlist(ctx context.Context, req *pb.CreateWishlistRequest) (*pb.WishlistResponse, error) {
+	wishlist, err := h.wishlistService.CreateWishlist(req.UserId, req.Name)
+	if err != nil {
+		return wishlistError(err)
+	}
+
+	return &pb.WishlistResponse{
+		Success:  true,
+		Message:  "Wishlist created successfully",
+		Wishlist: convertToWishlistData(wishlist),
+	}, nil
+}
+
+func (h *WishlistServiceHandler) ListWishlists(ctx context.Context, req *pb.ListWishlistsRequest) (*pb.ListWishlistsResponse, error) {
+	wishlists, err := h.wishlistService.ListWishlists(req.UserId)
+	if err != nil {
+		return &pb.ListWishlistsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	wishlistDataList := make([]*pb.WishlistData, 0, len(wishlists))
+	for i := range wishlists {
+		wishlistDataList = append(wishlistDataList, convertToWishlistData(&wishlists[i]))
+	}
+
+	return &pb.ListWishlistsResponse{
+		Success:   true,
+		Message:   "Wishlists retrieved successfully",
+		Wishlists: wishlistDataList,
+	}, nil
+}
+
+func (h *WishlistServiceHandler) GetWishlist(ctx context.Context, req *pb.GetWishlistRequest) (*pb.WishlistResponse, error) {
+	wishlist, err := h.wishlistService.GetWishlist(req.UserId, req.WishlistId)
+	if err != nil {
+		return wishlistError(err)
+	}
+
+	return &pb.WishlistResponse{
+		Success:  true,
+		Message:  "Wishlist retrieved successfully",
+		Wishlist: convertToWishlistData(wishlist),
+	}, nil
+}
+
+func (h *WishlistServiceHandler) RenameWishlist(ctx context.Context, req *pb.RenameWishlistRequest) (*pb.WishlistResponse, error) {
+	wishlist, err := h.wishlistService.RenameWishlist(req.UserId, req.WishlistId, req.Name)
+	if err != nil {
+		return wishlistError(err)
+	}
+
+	return &pb.WishlistResponse{
+		Success:  true,
+		Message:  "Wishlist renamed successfully",
+		Wishlist: convertToWishlistData(wishlist),
+	}, nil
+}
+
+func (h *WishlistServiceHandler) DeleteWishlist(ctx context.Context, req *pb.DeleteWishlistRequest) (*pb.DeleteWishlistResponse, error) {
+	err := h.wishlistService.DeleteWishlist(req.UserId, req.WishlistId)
+	if code, ok := wishlistStatus(err); ok {
+		return nil, status.Error(code, err.Error())
+	}
+	if err != nil {
+		return &pb.DeleteWishlistResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteWishlistResponse{
+		Success: true,
+		Message: "Wishlist deleted successfully",
+	}, nil
+}
+
+func (h *WishlistServiceHandler) AddToWishlist(ctx context.Context, req *pb.AddToWishlistRequest) (*pb.WishlistResponse, error) {
+	wishlist, err := h.wishlistService.AddToWishlist(req.UserId, req.WishlistId, req.ProductId)
+	if err != nil {
+		return wishlistError(err)
+	}
+
+	return &pb.WishlistResponse{
+		Success:  true,
+		Message:  "Item added to wishlist successfully",
+		Wishlist: convertToWishlistData(wishlist),
+	}, nil
+}
+
+func (h *WishlistServiceHandler) RemoveFromWishlist(ctx context.Context, req *pb.RemoveFromWishlistRequest) (*pb.WishlistResponse, error) {
+	wishlist, err := h.wishlistService.RemoveFromWishlist(req.UserId, req.WishlistId, req.ProductId)
+	if err != nil {
+		return wishlistError(err)
+	}
+
+	return &pb.WishlistResponse{
+		Success:  true,
+		Message:  "Item removed from wishlist successfully",
+		Wishlist: convertToWishlistData(wishlist),
+	}, nil
+}
+
+func (h *WishlistServiceHandler) MoveToCart(ctx context.Context, req *pb.MoveToCartRequest) (*pb.MoveToCartResponse, error) {
+	cart, err := h.wishlistService.MoveToCart(req.UserId, req.WishlistId, req.ProductId, int(req.Quantity))
+	if code, ok := wishlistStatus(err); ok {
+		return nil, status.Error(code, err.Error())
+	}
+	if err != nil {
+		return &pb.MoveToCartResponse{
+			Success:       false,
+			Message:       err.Error(),
+			QuantityError: convertToQuantityErrorData(err),
+		}, nil
+	}
+
+	return &pb.MoveToCartResponse{
+		Success: true,
+		Message: "Item moved to cart successfully",
+		Cart:    convertToCartData(cart),
+	}, nil
+}
+
+func (h *WishlistServiceHandler) MoveToWishlist(ctx context.Context, req *pb.MoveToWishlistRequest) (*pb.WishlistResponse, error) {
+	wishlist, err := h.wishlistService.MoveToWishlist(req.UserId, req.WishlistId, req.ProductId)
+	if err != nil {
+		return wishlistError(err)
+	}
+
+	return &pb.WishlistResponse{
+		Success:  true,
+		Message:  "Item moved to wishlist successfully",
+		Wishlist: convertToWishlistData(wishlist),
+	}, nil
+}
+
+func (h *WishlistServiceHandler) ListPriceDrops(ctx context.Context, req *pb.ListPriceDropsRequest) (*pb.ListPriceDropsResponse, error) {
+	items, err := h.wishlistService.ListPriceDrops(req.UserId)
+	if err != nil {
+		return &pb.ListPriceDropsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	itemDataList := make([]*pb.WishlistItemData, 0, len(items))
+	for i := range items {
+		itemDataList = append(itemDataList, convertToWishlistItemData(&items[i]))
+	}
+
+	return &pb.ListPriceDropsResponse{
+		Success: true,
+		Message: "Price drops retrieved successfully",
+		Items:   itemDataList,
+	}, nil
+}
+
+func (h *WishlistServiceHandler) ClearWishlists(ctx context.Context, req *pb.ClearWishlistsRequest) (*pb.ClearWishlistsResponse, error) {
+	err := h.wishlistService.ClearWishlists(req.UserId)
+	if err != nil {
+		return &pb.ClearWishlistsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ClearWishlistsResponse{
+		Success: true,
+		Message: "Wishlists cleared successfully",
+	}, nil
+}
+
+// wishlistError answers a failed RPC that returns a single wishlist
+func wishlistError(err error) (*pb.WishlistResponse, error) {
+	if code, ok := wishlistStatus(err); ok {
+		return nil, status.Error(code, err.Error())
+	}
+	return &pb.WishlistResponse{
+		Success: false,
+		Message: err.Error(),
+	}, nil
+}
+
+// wishlistStatus returns the gRPC code for the wishlist errors callers are
+// expected to handle
+func wishlistStatus(err error) (codes.Code, bool) {
+	switch {
+	case err == nil:
+		return codes.OK, false
+	case errors.Is(err, repository.ErrWishlistNotFound),
+		errors.Is(err, repository.ErrWishlistItemNotFound),
+		errors.Is(err, service.ErrProductNotInCart):
+		return codes.NotFound, true
+	case errors.Is(err, service.ErrWishlistNameTaken):
+		return codes.AlreadyExists, true
+	case errors.Is(err, service.ErrTooManyWishlists),
+		errors.Is(err, service.ErrDefaultWishlist):
+		return codes.FailedPrecondition, true
+	default:
+		return codes.OK, false
+	}
+}
+
+func convertToWishlistData(wishlist *models.Wishlist) *pb.WishlistData {
+	items := make([]*pb.WishlistItemData, 0, len(wishlist.Items))
+	for i := range wishlist.Items {
+		items = append(items, convertToWishlistItemData(&wishlist.Items[i]))
+	}
+
+	return &pb.WishlistData{
+		Id:        wishlist.ID,
+		UserId:    wishlist.UserID,
+		Name:      wishlist.Name,
+		IsDefault: wishlist.IsDefault,
+		Items:     items,
+		CreatedAt: wishlist.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: wishlist.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func convertToWishlistItemData(item *models.WishlistItem) *pb.WishlistItemData {
+	drop := item.PriceDrop()
+	return &pb.WishlistItemData{
+		Id:           item.ID,
+		WishlistId:   item.WishlistID,
+		ProductId:    item.ProductID,
+		ProductName:  item.ProductName,
+		ImageUrl:     item.ImageURL,
+		AddedPrice:   item.AddedPrice,
+		CurrentPrice: item.CurrentPrice,
+		PriceDropped: drop > 0,
+		PriceDrop:    drop,
+		Unavailable:  item.Unavailable,
+		AddedAt:      item.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,11 +9,28 @@ import (
 )
 
 type Cart struct {
-	ID        string     `gorm:"type:uuid;primary_key" json:"id"`
-	UserID    string     `gorm:"type:uuid;uniqueIndex;not null" json:"user_id"`
-	Items     []CartItem `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE" json:"items"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID string `gorm:"type:uuid;primary_key" json:"id"`
+	// UserID of a guest cart is a random ID carried in the guest's signed cart token
+	UserID     string     `gorm:"type:uuid;uniqueIndex;not null" json:"user_id"`
+	IsGuest    bool       `gorm:"default:false;index" json:"is_guest"`
+	CouponCode string     `gorm:"type:varchar(50)" json:"coupon_code"` // Applied coupon, checked with order service
+	Items      []CartItem `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE" json:"items"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Filled in from order service when the cart is read; not stored
+	Coupon *AppliedCoupon `gorm:"-" json:"coupon,omitempty"`
+}
+
+// AppliedCoupon is what the cart's coupon is worth on the cart as it is
+// now. A coupon that no longer applies stays on the cart with Valid false.
+type AppliedCoupon struct {
+	Code         string  `json:"code"`
+	Type         string  `json:"type"`
+	Discount     float64 `json:"discount"`
+	FreeShipping bool    `json:"free_shipping"`
+	Valid        bool    `json:"valid"`
+	Error        string  `json:"error,omitempty"`
 }
 
 type CartItem struct {
@@ -21,10 +39,15 @@ type CartItem struct {
 	ProductID   string    `gorm:"type:uuid;not null" json:"product_id"`
 	ProductName string    `gorm:"type:varchar(255)" json:"product_name"`
 	Quantity    int       `gorm:"not null;default:1" json:"quantity"`
-	Price       float64   `gorm:"type:decimal(10,2);not null" json:"price"`
+	Price       float64   `gorm:"type:decimal(10,2);not null" json:"price"` // Unit price when the item was added
 	ImageURL    string    `gorm:"type:varchar(500)" json:"image_url"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Filled in from product service when the cart is read; not stored
+	CurrentPrice float64 `gorm:"-" json:"current_price"`
+	Unavailable  bool    `gorm:"-" json:"unavailable"`  // Deleted or out of stock
+	MaxQuantity  int     `gorm:"-" json:"max_quantity"` // Most of the product the cart may hold
 }
 
 func (Cart) TableName() string {
@@ -49,8 +72,26 @@ func (ci *CartItem) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// UnitPrice is the price the customer would pay for one unit now, or the
+// price when added if the current price is unknown
+func (ci *CartItem) UnitPrice() float64 {
+	if ci.CurrentPrice > 0 {
+		return ci.CurrentPrice
+	}
+	return ci.Price
+}
+
+// PriceChanged reports whether the price changed since the item was added
+func (ci *CartItem) PriceChanged() bool {
+	return ci.CurrentPrice > 0 && RoundPrice(ci.CurrentPrice) != RoundPrice(ci.Price)
+}
+
+// GetSubtotal is zero for unavailable items, which cannot be ordered
 func (ci *CartItem) GetSubtotal() float64 {
-	return ci.Price * float64(ci.Quantity)
+	if ci.Unavailable {
+		return 0
+	}
+	return ci.UnitPrice() * float64(ci.Quantity)
 }
 
 func (c *Cart) GetTotalPrice() float64 {
@@ -61,6 +102,14 @@ func (c *Cart) GetTotalPrice() float64 {
 	return total
 }
 
+// GetDiscount is what the cart's coupon takes off the total price
+func (c *Cart) GetDiscount() float64 {
+	if c.Coupon == nil || !c.Coupon.Valid {
+		return 0
+	}
+	return RoundPrice(math.Min(c.Coupon.Discount, c.GetTotalPrice()))
+}
+
 func (c *Cart) GetTotalItems() int {
 	var total int
 	for _, item := range c.Items {
@@ -68,3 +117,40 @@ func (c *Cart) GetTotalItems() int {
 	}
 	return total
 }
+
+// RoundPrice rounds a price to cents, as prices are stored
+func RoundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
+
+// CartNudge records that the owner of an abandoned cart was notified.
+// LastActivityAt is when the cart's items were last changed before the
+// notice; the cart is only nudged again after its items change.
+type CartNudge struct {
+	ID             string    `gorm:"type:uuid;primary_key" json:"id"`
+	CartID         string    `gorm:"type:uuid;uniqueIndex;not null" json:"cart_id"`
+	LastActivityAt time.Time `gorm:"not null" json:"last_activity_at"`
+	NotifiedAt     time.Time `gorm:"not null" json:"notified_at"`
+}
+
+func (CartNudge) TableName() string {
+	return "cart_nudges"
+}
+
+func (cn *CartNudge) BeforeCreate(tx *gorm.DB) error {
+	if cn.ID == "" {
+		cn.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// LastActivity is when the cart's items were last added or changed
+func (c *Cart) LastActivity() time.Time {
+	var last time.Time
+	for _, item := range c.Items {
+		if item.UpdatedAt.After(last) {
+			last = item.UpdatedAt
+		}
+	}
+	return last
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultWishlistName is the name of the list every user has, which items
+// saved for later from the cart go to
+const DefaultWishlistName = "My Wishlist"
+
+// Wishlist is a named list of products a user has parked outside the cart
+type Wishlist struct {
+	ID        string         `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    string         `gorm:"type:uuid;not null;uniqueIndex:idx_wishlists_user_name" json:"user_id"`
+	Name      string         `gorm:"type:varchar(100);not null;uniqueIndex:idx_wishlists_user_name" json:"name"`
+	IsDefault bool           `gorm:"default:false" json:"is_default"`
+	Items     []WishlistItem `gorm:"foreignKey:WishlistID;constraint:OnDelete:CASCADE" json:"items"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// WishlistItem is a product on a wishlist. AddedPrice is the product's final
+// price when it was added, which price drops are measured against.
+type WishlistItem struct {
+	ID          string    `gorm:"type:uuid;primary_key" json:"id"`
+	WishlistID  string    `gorm:"type:uuid;not null;uniqueIndex:idx_wishlist_items_product" json:"wishlist_id"`
+	ProductID   string    `gorm:"type:uuid;not null;uniqueIndex:idx_wishlist_items_product" json:"product_id"`
+	ProductName string    `gorm:"type:varchar(255)" json:"product_name"`
+	ImageURL    string    `gorm:"type:varchar(500)" json:"image_url"`
+	AddedPrice  float64   `gorm:"type:decimal(10,2);not null" json:"added_price"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Filled in from product service when the wishlist is read; not stored
+	CurrentPrice float64 `gorm:"-" json:"current_price"`
+	Unavailable  bool    `gorm:"-" json:"unavailable"` // Deleted or out of stock
+}
+
+func (Wishlist) TableName() string {
+	return "wishlists"
+}
+
+func (WishlistItem) TableName() string {
+	return "wishlist_items"
+}
+
+func (w *Wishlist) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (wi *WishlistItem) BeforeCreate(tx *gorm.DB) error {
+	if wi.ID == "" {
+		wi.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// PriceDrop is how much the final price fell since the item was added, or
+// zero if it did not fall or the current price is unknown
+func (wi *WishlistItem) PriceDrop() float64 {
+	if wi.Unavailable || wi.CurrentPrice <= 0 {
+		return 0
+	}
+	drop := RoundPrice(wi.AddedPrice - wi.CurrentPrice)
+	if drop <= 0 {
+		return 0
+	}
+	return drop
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// CartAbandoned is emitted when a user leaves items in their cart without
+// touching it for the abandoned cart period
+type CartAbandoned struct {
+	CartID         string
+	UserID         string
+	Items          []AbandonedItem
+	TotalPrice     float64
+	TotalItems     int
+	LastActivityAt time.Time
+}
+
+// AbandonedItem is an item left in an abandoned cart, at the price it was
+// added to the cart at
+type AbandonedItem struct {
+	ProductID   string
+	ProductName string
+	ImageURL    string
+	Quantity    int
+	Price       float64
+}
+
+// Notifier delivers cart events such as abandoned cart reminders to users
+type Notifier interface {
+	NotifyCartAbandoned(event CartAbandoned) error
+}
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a notifier for local development that writes
+// events to the given file, or to the service log if path is empty,
+// instead of sending them
+func NewLogNotifier(path string) (Notifier, error) {
+	var out io.Writer = os.Stderr
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		out = file
+	}
+
+	return &logNotifier{logger: log.New(out, "[notifier] ", log.LstdFlags)}, nil
+}
+
+// NotifyCartAbandoned records the abandoned cart reminder for the cart's owner
+func (n *logNotifier) NotifyCartAbandoned(event CartAbandoned) error {
+	n.logger.Printf("cart abandoned by user %s: cart=%s items=%d total=%.2f last_activity=%s",
+		event.UserID, event.CartID, event.TotalItems, event.TotalPrice, event.LastActivityAt.Format(time.RFC3339))
+	return nil
+}
@@ -2,19 +2,32 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"jumia-clone-backend/services/cart-service/internal/models"
 
 	"gorm.io/gorm"
 )
 
+// ErrGuestCartNotFound is returned when a cart token refers to a guest cart
+// that was merged into a user's cart or expired
+var ErrGuestCartNotFound = errors.New("guest cart not found or expired")
+
 type CartRepository interface {
 	GetOrCreateCart(userID string) (*models.Cart, error)
 	AddItem(cartID, productID, productName, imageURL string, quantity int, price float64) (*models.CartItem, error)
 	UpdateItem(cartID, productID string, quantity int) error
 	RemoveItem(cartID, productID string) error
 	GetCart(userID string) (*models.Cart, error)
-	ClearCart(userID string) error
+	ClearCart(cartID string) error
+	DeleteUserCart(userID string) error
+	CreateGuestCart(guestID string) (*models.Cart, error)
+	GetGuestCart(guestID string) (*models.Cart, error)
+	MergeCarts(guestCartID, userCartID string) error
+	DeleteStaleGuestCarts(before time.Time) (int64, error)
+	FindAbandonedCarts(before time.Time, limit int) ([]models.Cart, error)
+	RecordNudge(cartID string, lastActivity time.Time) error
+	SetCouponCode(cartID, code string) error
 }
 
 type cartRepository struct {
@@ -42,12 +55,23 @@ func (r *cartRepository) GetOrCreateCart(userID string) (*models.Cart, error) {
 }
 
 func (r *cartRepository) AddItem(cartID, productID, productName, imageURL string, quantity int, price float64) (*models.CartItem, error) {
+	return addCartItem(r.db, cartID, productID, productName, imageURL, quantity, price)
+}
+
+// addCartItem adds quantity units of a product to a cart with db, which may
+// be a transaction
+func addCartItem(db *gorm.DB, cartID, productID, productName, imageURL string, quantity int, price float64) (*models.CartItem, error) {
 	var existingItem models.CartItem
-	err := r.db.Where("cart_id = ? AND product_id = ?", cartID, productID).First(&existingItem).Error
+	err := db.Where("cart_id = ? AND product_id = ?", cartID, productID).First(&existingItem).Error
 
 	if err == nil {
+		// Adding the product again also takes on its current details, which
+		// clears a price change flag
 		existingItem.Quantity += quantity
-		if err := r.db.Save(&existingItem).Error; err != nil {
+		existingItem.ProductName = productName
+		existingItem.ImageURL = imageURL
+		existingItem.Price = price
+		if err := db.Save(&existingItem).Error; err != nil {
 			return nil, err
 		}
 		return &existingItem, nil
@@ -66,7 +90,7 @@ func (r *cartRepository) AddItem(cartID, productID, productName, imageURL string
 		Price:       price,
 	}
 
-	if err := r.db.Create(&item).Error; err != nil {
+	if err := db.Create(&item).Error; err != nil {
 		return nil, err
 	}
 
@@ -85,7 +109,12 @@ func (r *cartRepository) UpdateItem(cartID, productID string, quantity int) erro
 }
 
 func (r *cartRepository) RemoveItem(cartID, productID string) error {
-	return r.db.Where("cart_id = ? AND product_id = ?", cartID, productID).Delete(&models.CartItem{}).Error
+	return removeCartItem(r.db, cartID, productID)
+}
+
+// removeCartItem removes a product from a cart with db, which may be a transaction
+func removeCartItem(db *gorm.DB, cartID, productID string) error {
+	return db.Where("cart_id = ? AND product_id = ?", cartID, productID).Delete(&models.CartItem{}).Error
 }
 
 func (r *cartRepository) GetCart(userID string) (*models.Cart, error) {
@@ -100,15 +129,149 @@ func (r *cartRepository) GetCart(userID string) (*models.Cart, error) {
 	return &cart, nil
 }
 
-func (r *cartRepository) ClearCart(userID string) error {
+// ClearCart removes every item and the coupon from a cart
+func (r *cartRepository) ClearCart(cartID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Cart{}).Where("id = ?", cartID).Update("coupon_code", "").Error; err != nil {
+			return err
+		}
+		return tx.Where("cart_id = ?", cartID).Delete(&models.CartItem{}).Error
+	})
+}
+
+// DeleteUserCart deletes a user's cart with its items and reminder records,
+// leaving nothing that refers to the user
+func (r *cartRepository) DeleteUserCart(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var cartIDs []string
+		err := tx.Model(&models.Cart{}).
+			Where("user_id = ? AND is_guest = ?", userID, false).
+			Pluck("id", &cartIDs).Error
+		if err != nil || len(cartIDs) == 0 {
+			return err
+		}
+
+		for _, model := range []interface{}{&models.CartNudge{}, &models.CartItem{}} {
+			if err := tx.Where("cart_id IN ?", cartIDs).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id IN ?", cartIDs).Delete(&models.Cart{}).Error
+	})
+}
+
+func (r *cartRepository) CreateGuestCart(guestID string) (*models.Cart, error) {
+	cart := models.Cart{UserID: guestID, IsGuest: true, Items: []models.CartItem{}}
+	if err := r.db.Create(&cart).Error; err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+func (r *cartRepository) GetGuestCart(guestID string) (*models.Cart, error) {
 	var cart models.Cart
-	err := r.db.Where("user_id = ?", userID).First(&cart).Error
+	err := r.db.Where("user_id = ? AND is_guest = ?", guestID, true).Preload("Items").First(&cart).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil
+			return nil, ErrGuestCartNotFound
 		}
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// MergeCarts moves the items of a guest cart into a user's cart, adding up
+// the quantities of products that are in both, and deletes the guest cart
+func (r *cartRepository) MergeCarts(guestCartID, userCartID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var guestItems []models.CartItem
+		if err := tx.Where("cart_id = ?", guestCartID).Find(&guestItems).Error; err != nil {
+			return err
+		}
+
+		for _, guestItem := range guestItems {
+			var item models.CartItem
+			err := tx.Where("cart_id = ? AND product_id = ?", userCartID, guestItem.ProductID).First(&item).Error
+			if err == nil {
+				item.Quantity += guestItem.Quantity
+				if err := tx.Save(&item).Error; err != nil {
+					return err
+				}
+				if err := tx.Delete(&guestItem).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if err := tx.Model(&guestItem).Update("cart_id", userCartID).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Where("id = ? AND is_guest = ?", guestCartID, true).Delete(&models.Cart{}).Error
+	})
+}
+
+// DeleteStaleGuestCarts deletes guest carts that have not been touched since
+// before, together with their items
+func (r *cartRepository) DeleteStaleGuestCarts(before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var cartIDs []string
+		err := tx.Model(&models.Cart{}).
+			Where("is_guest = ? AND updated_at < ?", true, before).
+			Where("NOT EXISTS (SELECT 1 FROM cart_items WHERE cart_items.cart_id = carts.id AND cart_items.updated_at >= ?)", before).
+			Pluck("id", &cartIDs).Error
+		if err != nil || len(cartIDs) == 0 {
+			return err
+		}
+
+		if err := tx.Where("cart_id IN ?", cartIDs).Delete(&models.CartItem{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id IN ?", cartIDs).Delete(&models.Cart{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
+
+// FindAbandonedCarts returns user carts, with their items, whose items were
+// all last changed before before and that were not nudged since. Carts idle
+// the longest come first.
+func (r *cartRepository) FindAbandonedCarts(before time.Time, limit int) ([]models.Cart, error) {
+	var carts []models.Cart
+	err := r.db.Select("carts.*").
+		Joins("JOIN (SELECT cart_id, MAX(updated_at) AS last_activity FROM cart_items GROUP BY cart_id) activity ON activity.cart_id = carts.id").
+		Joins("LEFT JOIN cart_nudges ON cart_nudges.cart_id = carts.id").
+		Where("carts.is_guest = ? AND activity.last_activity < ?", false, before).
+		Where("cart_nudges.id IS NULL OR cart_nudges.last_activity_at < activity.last_activity").
+		Order("activity.last_activity ASC").
+		Limit(limit).
+		Preload("Items").
+		Find(&carts).Error
+	return carts, err
+}
+
+// RecordNudge records that the owner of a cart was notified about the items
+// last changed at lastActivity
+func (r *cartRepository) RecordNudge(cartID string, lastActivity time.Time) error {
+	var nudge models.CartNudge
+	err := r.db.Where("cart_id = ?", cartID).First(&nudge).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return err
 	}
 
-	return r.db.Where("cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error
+	nudge.CartID = cartID
+	nudge.LastActivityAt = lastActivity
+	nudge.NotifiedAt = time.Now()
+	return r.db.Save(&nudge).Error
+}
+
+// SetCouponCode sets the coupon applied to a cart; an empty code removes it
+func (r *cartRepository) SetCouponCode(cartID, code string) error {
+	return r.db.Model(&models.Cart{}).Where("id = ?", cartID).Update("coupon_code", code).Error
 }
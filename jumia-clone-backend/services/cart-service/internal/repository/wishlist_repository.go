@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"errors"
+
+	"jumia-clone-backend/services/cart-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrWishlistNotFound     = errors.New("wishlist not found")
+	ErrWishlistItemNotFound = errors.New("product is not on the wishlist")
+)
+
+type WishlistRepository interface {
+	Create(wishlist *models.Wishlist) error
+	ListByUser(userID string) ([]models.Wishlist, error)
+	CountByUser(userID string) (int64, error)
+	Get(userID, wishlistID string) (*models.Wishlist, error)
+	GetByName(userID, name string) (*models.Wishlist, error)
+	GetOrCreateDefault(userID string) (*models.Wishlist, error)
+	Rename(wishlist *models.Wishlist, name string) error
+	Delete(userID, wishlistID string) error
+	DeleteAllForUser(userID string) error
+	AddItem(item *models.WishlistItem) error
+	RemoveItem(wishlistID, productID string) error
+	MoveToCart(wishlistID string, item *models.CartItem) error
+	MoveFromCart(cartID string, item *models.WishlistItem) error
+}
+
+type wishlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWishlistRepository(db *gorm.DB) WishlistRepository {
+	return &wishlistRepository{db: db}
+}
+
+func (r *wishlistRepository) Create(wishlist *models.Wishlist) error {
+	return r.db.Create(wishlist).Error
+}
+
+// ListByUser returns the user's wishlists with their items, the default list first
+func (r *wishlistRepository) ListByUser(userID string) ([]models.Wishlist, error) {
+	var wishlists []models.Wishlist
+	err := r.db.Where("user_id = ?", userID).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("created_at DESC") }).
+		Order("is_default DESC, created_at ASC").
+		Find(&wishlists).Error
+	return wishlists, err
+}
+
+func (r *wishlistRepository) CountByUser(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Wishlist{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Get returns a wishlist of the user with its items
+func (r *wishlistRepository) Get(userID, wishlistID string) (*models.Wishlist, error) {
+	var wishlist models.Wishlist
+	err := r.db.Where("id = ? AND user_id = ?", wishlistID, userID).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("created_at DESC") }).
+		First(&wishlist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWishlistNotFound
+		}
+		return nil, err
+	}
+	return &wishlist, nil
+}
+
+func (r *wishlistRepository) GetByName(userID, name string) (*models.Wishlist, error) {
+	var wishlist models.Wishlist
+	err := r.db.Where("user_id = ? AND LOWER(name) = LOWER(?)", userID, name).First(&wishlist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWishlistNotFound
+		}
+		return nil, err
+	}
+	return &wishlist, nil
+}
+
+func (r *wishlistRepository) GetOrCreateDefault(userID string) (*models.Wishlist, error) {
+	var wishlist models.Wishlist
+	err := r.db.Where("user_id = ? AND is_default = ?", userID, true).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("created_at DESC") }).
+		First(&wishlist).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			wishlist = models.Wishlist{
+				UserID:    userID,
+				Name:      models.DefaultWishlistName,
+				IsDefault: true,
+				Items:     []models.WishlistItem{},
+			}
+			if err := r.db.Create(&wishlist).Error; err != nil {
+				return nil, err
+			}
+			return &wishlist, nil
+		}
+		return nil, err
+	}
+	return &wishlist, nil
+}
+
+func (r *wishlistRepository) Rename(wishlist *models.Wishlist, name string) error {
+	return r.db.Model(wishlist).Update("name", name).Error
+}
+
+func (r *wishlistRepository) Delete(userID, wishlistID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", wishlistID, userID).Delete(&models.Wishlist{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrWishlistNotFound
+		}
+		return tx.Where("wishlist_id = ?", wishlistID).Delete(&models.WishlistItem{}).Error
+	})
+}
+
+// DeleteAllForUser deletes every wishlist of the user with their items
+func (r *wishlistRepository) DeleteAllForUser(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := tx.Model(&models.Wishlist{}).Select("id").Where("user_id = ?", userID)
+		if err := tx.Where("wishlist_id IN (?)", ids).Delete(&models.WishlistItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.Wishlist{}).Error
+	})
+}
+
+// AddItem adds a product to a wishlist. A product already on the list keeps
+// the price it was added at, so earlier price drops are not lost.
+func (r *wishlistRepository) AddItem(item *models.WishlistItem) error {
+	return addWishlistItem(r.db, item)
+}
+
+func (r *wishlistRepository) RemoveItem(wishlistID, productID string) error {
+	return removeWishlistItem(r.db, wishlistID, productID)
+}
+
+// MoveToCart takes a product off a wishlist and adds item to its cart in one
+// transaction. If the product is no longer on the list nothing is added, so
+// a repeated move cannot add it twice.
+func (r *wishlistRepository) MoveToCart(wishlistID string, item *models.CartItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := removeWishlistItem(tx, wishlistID, item.ProductID); err != nil {
+			return err
+		}
+		_, err := addCartItem(tx, item.CartID, item.ProductID, item.ProductName, item.ImageURL, item.Quantity, item.Price)
+		return err
+	})
+}
+
+// MoveFromCart puts item on its wishlist and removes the product from a cart
+// in one transaction
+func (r *wishlistRepository) MoveFromCart(cartID string, item *models.WishlistItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := addWishlistItem(tx, item); err != nil {
+			return err
+		}
+		return removeCartItem(tx, cartID, item.ProductID)
+	})
+}
+
+// addWishlistItem adds a product to a wishlist with db, which may be a transaction
+func addWishlistItem(db *gorm.DB, item *models.WishlistItem) error {
+	var existing models.WishlistItem
+	err := db.Where("wishlist_id = ? AND product_id = ?", item.WishlistID, item.ProductID).First(&existing).Error
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return db.Create(item).Error
+}
+
+// removeWishlistItem removes a product from a wishlist with db, which may be a transaction
+func removeWishlistItem(db *gorm.DB, wishlistID, productID string) error {
+	result := db.Where("wishlist_id = ? AND product_id = ?", wishlistID, productID).Delete(&models.WishlistItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWishlistItemNotFound
+	}
+	return nil
+}
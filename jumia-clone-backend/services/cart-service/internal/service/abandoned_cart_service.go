@@ -0,0 +1,80 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"jumia-clone-backend/services/cart-service/internal/models"
+	"jumia-clone-backend/services/cart-service/internal/notifier"
+	"jumia-clone-backend/services/cart-service/internal/repository"
+)
+
+// abandonedCartBatchSize limits how many carts one run notifies about; the
+// rest are picked up by the next run
+const abandonedCartBatchSize = 100
+
+// AbandonedCartService finds user carts whose items nobody touched for the
+// abandoned cart period and notifies their owners once per abandonment
+type AbandonedCartService interface {
+	NotifyAbandonedCarts() (int, error)
+}
+
+type abandonedCartService struct {
+	repo         repository.CartRepository
+	notify       notifier.Notifier
+	abandonAfter time.Duration
+}
+
+// NewAbandonedCartService creates the service. A cart is abandoned when its
+// items were last added or changed more than abandonAfter ago.
+func NewAbandonedCartService(repo repository.CartRepository, notify notifier.Notifier, abandonAfter time.Duration) AbandonedCartService {
+	return &abandonedCartService{repo: repo, notify: notify, abandonAfter: abandonAfter}
+}
+
+// NotifyAbandonedCarts emits a CartAbandoned event for each abandoned cart
+// that was not nudged since its items last changed, and returns how many
+// were sent. A cart whose notification fails is retried on the next run.
+func (s *abandonedCartService) NotifyAbandonedCarts() (int, error) {
+	carts, err := s.repo.FindAbandonedCarts(time.Now().Add(-s.abandonAfter), abandonedCartBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range carts {
+		cart := &carts[i]
+		event := cartAbandonedEvent(cart)
+
+		if err := s.notify.NotifyCartAbandoned(event); err != nil {
+			log.Printf("Failed to notify user %s about abandoned cart %s: %v", cart.UserID, cart.ID, err)
+			continue
+		}
+		if err := s.repo.RecordNudge(cart.ID, event.LastActivityAt); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func cartAbandonedEvent(cart *models.Cart) notifier.CartAbandoned {
+	items := make([]notifier.AbandonedItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, notifier.AbandonedItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			ImageURL:    item.ImageURL,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+		})
+	}
+
+	return notifier.CartAbandoned{
+		CartID:         cart.ID,
+		UserID:         cart.UserID,
+		Items:          items,
+		TotalPrice:     models.RoundPrice(cart.GetTotalPrice()),
+		TotalItems:     cart.GetTotalItems(),
+		LastActivityAt: cart.LastActivity(),
+	}
+}
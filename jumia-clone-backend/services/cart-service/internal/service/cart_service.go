@@ -1,42 +1,164 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"jumia-clone-backend/services/cart-service/internal/client"
 	"jumia-clone-backend/services/cart-service/internal/models"
 	"jumia-clone-backend/services/cart-service/internal/repository"
+	pb "jumia-clone-backend/services/cart-service/proto"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrCartOwnerRequired is returned when a request names neither a user nor a guest cart
+	ErrCartOwnerRequired = errors.New("user_id or cart_token is required")
+	// ErrGuestCoupon is returned when a guest tries to use a coupon; usage
+	// limits are per user, so coupons need an account
+	ErrGuestCoupon = errors.New("log in to use a coupon")
+)
+
+// Codes of a QuantityError
+const (
+	QuantityInvalid   = "invalid_quantity"
+	QuantityOverLimit = "max_per_order_exceeded"
+	QuantityOverStock = "insufficient_stock"
 )
 
+// QuantityError is returned when a cart quantity is below 1 or above what
+// the cart may hold of a product. MaxQuantity is the most the cart may hold.
+type QuantityError struct {
+	Code        string
+	ProductID   string
+	MaxQuantity int
+}
+
+func (e *QuantityError) Error() string {
+	switch e.Code {
+	case QuantityOverLimit:
+		return fmt.Sprintf("at most %d of this product can be ordered at once", e.MaxQuantity)
+	case QuantityOverStock:
+		if e.MaxQuantity == 0 {
+			return "product is out of stock"
+		}
+		return fmt.Sprintf("only %d of this product are in stock", e.MaxQuantity)
+	default:
+		return "quantity must be at least 1"
+	}
+}
+
+// CartOwner identifies a cart: a signed-in user's cart by user ID, or a
+// guest cart by its cart token. The user ID takes precedence.
+type CartOwner struct {
+	UserID    string
+	CartToken string
+}
+
 type CartService interface {
-	AddToCart(userID, productID, productName, imageURL string, quantity int, price float64) (*models.Cart, error)
-	UpdateCartItem(userID, productID string, quantity int) (*models.Cart, error)
-	RemoveFromCart(userID, productID string) (*models.Cart, error)
-	GetCart(userID string) (*models.Cart, error)
-	ClearCart(userID string) error
+	AddToCart(owner CartOwner, productID string, quantity int) (*models.Cart, error)
+	PrepareCartItem(owner CartOwner, productID string, quantity int) (*models.CartItem, error)
+	UpdateCartItem(owner CartOwner, productID string, quantity int) (*models.Cart, error)
+	RemoveFromCart(owner CartOwner, productID string) (*models.Cart, error)
+	GetCart(owner CartOwner) (*models.Cart, error)
+	ClearCart(owner CartOwner) error
+	EraseUserCart(userID string) error
+	CreateGuestCart() (*models.Cart, string, error)
+	MergeCarts(userID, cartToken string) (*models.Cart, error)
+	CleanupGuestCarts() (int64, error)
+	ApplyCoupon(owner CartOwner, code string) (*models.Cart, error)
+	RemoveCoupon(owner CartOwner) (*models.Cart, error)
 }
 
 type cartService struct {
-	repo repository.CartRepository
+	repo          repository.CartRepository
+	products      client.ProductClient
+	coupons       client.CouponClient
+	tokens        *CartTokenSigner
+	guestCartTTL  time.Duration
+	maxPerProduct int
 }
 
-func NewCartService(repo repository.CartRepository) CartService {
-	return &cartService{repo: repo}
+// NewCartService creates the cart service. maxPerProduct limits the units of
+// a product in a cart unless the product sets its own max_per_order.
+func NewCartService(repo repository.CartRepository, products client.ProductClient, coupons client.CouponClient, tokens *CartTokenSigner, guestCartTTL time.Duration, maxPerProduct int) CartService {
+	return &cartService{repo: repo, products: products, coupons: coupons, tokens: tokens, guestCartTTL: guestCartTTL, maxPerProduct: maxPerProduct}
 }
 
-func (s *cartService) AddToCart(userID, productID, productName, imageURL string, quantity int, price float64) (*models.Cart, error) {
-	cart, err := s.repo.GetOrCreateCart(userID)
+// AddToCart adds a product with its name, image and price as product service
+// has them now; clients cannot set them
+func (s *cartService) AddToCart(owner CartOwner, productID string, quantity int) (*models.Cart, error) {
+	item, err := s.PrepareCartItem(owner, productID, quantity)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.repo.AddItem(cart.ID, productID, productName, imageURL, quantity, price)
+	_, err = s.repo.AddItem(item.CartID, item.ProductID, item.ProductName, item.ImageURL, item.Quantity, item.Price)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.repo.GetCart(userID)
+	return s.GetCart(owner)
 }
 
-func (s *cartService) UpdateCartItem(userID, productID string, quantity int) (*models.Cart, error) {
-	cart, err := s.repo.GetOrCreateCart(userID)
+// PrepareCartItem checks that quantity more units of a product fit in the
+// owner's cart and returns the item AddToCart would add, without saving it
+func (s *cartService) PrepareCartItem(owner CartOwner, productID string, quantity int) (*models.CartItem, error) {
+	if quantity < 1 {
+		return nil, &QuantityError{Code: QuantityInvalid, ProductID: productID}
+	}
+
+	product, err := s.getProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.cartFor(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	inCart := 0
+	for _, item := range cart.Items {
+		if item.ProductID == product.Id {
+			inCart = item.Quantity
+		}
+	}
+	if err := s.checkQuantity(product, inCart+quantity); err != nil {
+		return nil, err
+	}
+
+	return &models.CartItem{
+		CartID:      cart.ID,
+		ProductID:   product.Id,
+		ProductName: product.Name,
+		ImageURL:    product.ImageUrl,
+		Quantity:    quantity,
+		Price:       models.RoundPrice(unitPrice(product)),
+	}, nil
+}
+
+// UpdateCartItem sets the quantity of a product in the cart; zero removes it
+func (s *cartService) UpdateCartItem(owner CartOwner, productID string, quantity int) (*models.Cart, error) {
+	if quantity < 0 {
+		return nil, &QuantityError{Code: QuantityInvalid, ProductID: productID}
+	}
+	if quantity == 0 {
+		return s.RemoveFromCart(owner, productID)
+	}
+
+	product, err := s.getProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkQuantity(product, quantity); err != nil {
+		return nil, err
+	}
+
+	cart, err := s.cartFor(owner)
 	if err != nil {
 		return nil, err
 	}
@@ -46,11 +168,11 @@ func (s *cartService) UpdateCartItem(userID, productID string, quantity int) (*m
 		return nil, err
 	}
 
-	return s.repo.GetCart(userID)
+	return s.GetCart(owner)
 }
 
-func (s *cartService) RemoveFromCart(userID, productID string) (*models.Cart, error) {
-	cart, err := s.repo.GetOrCreateCart(userID)
+func (s *cartService) RemoveFromCart(owner CartOwner, productID string) (*models.Cart, error) {
+	cart, err := s.cartFor(owner)
 	if err != nil {
 		return nil, err
 	}
@@ -60,13 +182,311 @@ func (s *cartService) RemoveFromCart(userID, productID string) (*models.Cart, er
 		return nil, err
 	}
 
-	return s.repo.GetCart(userID)
+	return s.GetCart(owner)
+}
+
+// GetCart returns the cart with every item checked against product service
+// and its coupon checked against order service
+func (s *cartService) GetCart(owner CartOwner) (*models.Cart, error) {
+	ownerID, guest, err := s.resolveOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var cart *models.Cart
+	if guest {
+		cart, err = s.repo.GetGuestCart(ownerID)
+	} else {
+		cart, err = s.repo.GetCart(ownerID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.revalidate(cart)
+	s.checkCoupon(cart)
+	return cart, nil
+}
+
+// ClearCart empties the owner's cart. A guest cart is looked up among guest
+// carts only, so a cart token can never reach a user's cart.
+func (s *cartService) ClearCart(owner CartOwner) error {
+	ownerID, guest, err := s.resolveOwner(owner)
+	if err != nil {
+		return err
+	}
+
+	var cart *models.Cart
+	if guest {
+		cart, err = s.repo.GetGuestCart(ownerID)
+	} else {
+		cart, err = s.repo.GetCart(ownerID)
+	}
+	if err != nil {
+		return err
+	}
+	if cart.ID == "" {
+		return nil
+	}
+	return s.repo.ClearCart(cart.ID)
+}
+
+// EraseUserCart deletes the user's cart and the reminders sent about it,
+// for account erasure
+func (s *cartService) EraseUserCart(userID string) error {
+	if userID == "" {
+		return errors.New("user ID is required")
+	}
+	return s.repo.DeleteUserCart(userID)
+}
+
+// CreateGuestCart starts an empty cart for a visitor who is not logged in
+// and returns it with the cart token that identifies it
+func (s *cartService) CreateGuestCart() (*models.Cart, string, error) {
+	guestID := uuid.New().String()
+	cart, err := s.repo.CreateGuestCart(guestID)
+	if err != nil {
+		return nil, "", err
+	}
+	return cart, s.tokens.Sign(guestID), nil
+}
+
+// MergeCarts moves the items of a guest cart into the user's cart when the
+// guest logs in. Merging a cart that is already merged does nothing. Merged
+// quantities above what the cart may hold are lowered to the maximum.
+func (s *cartService) MergeCarts(userID, cartToken string) (*models.Cart, error) {
+	if userID == "" || cartToken == "" {
+		return nil, errors.New("user_id and cart_token are required")
+	}
+	guestID, err := s.tokens.Verify(cartToken)
+	if err != nil {
+		return nil, err
+	}
+
+	guestCart, err := s.repo.GetGuestCart(guestID)
+	if errors.Is(err, repository.ErrGuestCartNotFound) {
+		return s.GetCart(CartOwner{UserID: userID})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	userCart, err := s.repo.GetOrCreateCart(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.MergeCarts(guestCart.ID, userCart.ID); err != nil {
+		return nil, err
+	}
+
+	cart, err := s.GetCart(CartOwner{UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		if item.Unavailable || item.MaxQuantity == 0 || item.Quantity <= item.MaxQuantity {
+			continue
+		}
+		if err := s.repo.UpdateItem(cart.ID, item.ProductID, item.MaxQuantity); err != nil {
+			return nil, err
+		}
+		item.Quantity = item.MaxQuantity
+	}
+	return cart, nil
+}
+
+// ApplyCoupon puts a coupon on the user's cart if it applies to the cart now.
+// The coupon is checked again whenever the cart is read, and redeemed when
+// the cart is checked out.
+func (s *cartService) ApplyCoupon(owner CartOwner, code string) (*models.Cart, error) {
+	if owner.UserID == "" {
+		return nil, ErrGuestCoupon
+	}
+
+	cart, err := s.repo.GetOrCreateCart(owner.UserID)
+	if err != nil {
+		return nil, err
+	}
+	s.revalidate(cart)
+
+	quote, err := s.coupons.ValidateCoupon(code, owner.UserID, couponItems(cart))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetCouponCode(cart.ID, quote.Code); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(owner)
+}
+
+// RemoveCoupon takes the coupon off the user's cart
+func (s *cartService) RemoveCoupon(owner CartOwner) (*models.Cart, error) {
+	if owner.UserID == "" {
+		return nil, ErrGuestCoupon
+	}
+
+	cart, err := s.repo.GetOrCreateCart(owner.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetCouponCode(cart.ID, ""); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(owner)
+}
+
+// CleanupGuestCarts deletes guest carts nobody has touched for the guest cart TTL
+func (s *cartService) CleanupGuestCarts() (int64, error) {
+	return s.repo.DeleteStaleGuestCarts(time.Now().Add(-s.guestCartTTL))
+}
+
+// cartFor returns the cart to modify. A user's cart is created on first use;
+// a guest cart must have been created with CreateGuestCart.
+func (s *cartService) cartFor(owner CartOwner) (*models.Cart, error) {
+	ownerID, guest, err := s.resolveOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+	if guest {
+		return s.repo.GetGuestCart(ownerID)
+	}
+	return s.repo.GetOrCreateCart(ownerID)
+}
+
+// resolveOwner returns the user_id column value of the owner's cart and
+// whether it is a guest cart
+func (s *cartService) resolveOwner(owner CartOwner) (string, bool, error) {
+	if owner.UserID != "" {
+		return owner.UserID, false, nil
+	}
+	if owner.CartToken == "" {
+		return "", false, ErrCartOwnerRequired
+	}
+
+	guestID, err := s.tokens.Verify(owner.CartToken)
+	if err != nil {
+		return "", false, err
+	}
+	return guestID, true, nil
+}
+
+// revalidate fills in each item's current price and availability from
+// product service. If product service cannot be reached the items keep the
+// prices they were added at, so the cart can still be shown.
+func (s *cartService) revalidate(cart *models.Cart) {
+	for i := range cart.Items {
+		item := &cart.Items[i]
+
+		product, err := s.products.GetProduct(item.ProductID)
+		if errors.Is(err, client.ErrProductNotFound) {
+			item.Unavailable = true
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to revalidate product %s in cart %s: %v", item.ProductID, cart.ID, err)
+			continue
+		}
+
+		item.CurrentPrice = models.RoundPrice(unitPrice(product))
+		item.Unavailable = !product.InStock
+		item.MaxQuantity = s.maxQuantity(product)
+	}
 }
 
-func (s *cartService) GetCart(userID string) (*models.Cart, error) {
-	return s.repo.GetCart(userID)
+// checkCoupon works out what the cart's coupon is worth on the cart as it is
+// now. A coupon that stopped applying, say because items were removed, stays
+// on the cart so it applies again once the cart qualifies.
+func (s *cartService) checkCoupon(cart *models.Cart) {
+	if cart.CouponCode == "" || cart.IsGuest {
+		return
+	}
+
+	cart.Coupon = &models.AppliedCoupon{Code: cart.CouponCode}
+	quote, err := s.coupons.ValidateCoupon(cart.CouponCode, cart.UserID, couponItems(cart))
+	if err != nil {
+		var rejected *client.CouponRejectedError
+		if !errors.As(err, &rejected) {
+			log.Printf("Failed to check coupon %s on cart %s: %v", cart.CouponCode, cart.ID, err)
+			cart.Coupon.Error = "coupon could not be checked"
+			return
+		}
+		cart.Coupon.Error = rejected.Message
+		return
+	}
+
+	cart.Coupon.Type = quote.Type
+	cart.Coupon.Discount = quote.Discount
+	cart.Coupon.FreeShipping = quote.FreeShipping
+	cart.Coupon.Valid = true
 }
 
-func (s *cartService) ClearCart(userID string) error {
-	return s.repo.ClearCart(userID)
+// couponItems returns the cart items a coupon is priced against; unavailable
+// items cannot be ordered and so do not count
+func couponItems(cart *models.Cart) []client.CouponItem {
+	items := make([]client.CouponItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		if item.Unavailable {
+			continue
+		}
+		items = append(items, client.CouponItem{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return items
+}
+
+// getProduct looks up a product that is about to be added or changed
+func (s *cartService) getProduct(productID string) (*pb.ProductData, error) {
+	product, err := s.products.GetProduct(productID)
+	if err != nil {
+		if errors.Is(err, client.ErrProductNotFound) {
+			return nil, errors.New("product is not available")
+		}
+		return nil, err
+	}
+	return product, nil
+}
+
+// checkQuantity checks that the cart may hold quantity units of the product
+func (s *cartService) checkQuantity(product *pb.ProductData, quantity int) error {
+	if limit := s.perOrderLimit(product); limit > 0 && quantity > limit {
+		return &QuantityError{Code: QuantityOverLimit, ProductID: product.Id, MaxQuantity: s.maxQuantity(product)}
+	}
+	if !product.InStock || quantity > int(product.Stock) {
+		return &QuantityError{Code: QuantityOverStock, ProductID: product.Id, MaxQuantity: s.maxQuantity(product)}
+	}
+	return nil
+}
+
+// maxQuantity returns the most units of the product the cart may hold: the
+// per-order limit, capped at current stock
+func (s *cartService) maxQuantity(product *pb.ProductData) int {
+	if !product.InStock {
+		return 0
+	}
+	most := int(product.Stock)
+	if limit := s.perOrderLimit(product); limit > 0 && limit < most {
+		most = limit
+	}
+	return most
+}
+
+// perOrderLimit returns the product's max_per_order, or the default limit
+// when the product sets none. Zero means no limit.
+func (s *cartService) perOrderLimit(product *pb.ProductData) int {
+	if product.MaxPerOrder > 0 {
+		return int(product.MaxPerOrder)
+	}
+	return s.maxPerProduct
+}
+
+// unitPrice returns the price a customer pays for one unit of the product:
+// the flash sale price while a flash sale is running, otherwise the
+// discounted final price. Order service prices orders the same way.
+func unitPrice(product *pb.ProductData) float64 {
+	if product.IsFlashSaleActive && product.FlashSalePrice > 0 {
+		return product.FlashSalePrice
+	}
+	return product.FinalPrice
 }
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"jumia-clone-backend/services/cart-service/internal/models"
+	"jumia-clone-backend/services/cart-service/internal/repository"
+)
+
+// clearCartRepository holds one user cart and no guest carts
+type clearCartRepository struct {
+	repository.CartRepository
+	userCart *models.Cart
+	cleared  []string
+}
+
+func (r *clearCartRepository) GetCart(userID string) (*models.Cart, error) {
+	if userID == r.userCart.UserID {
+		return r.userCart, nil
+	}
+	return &models.Cart{UserID: userID}, nil
+}
+
+func (r *clearCartRepository) GetGuestCart(guestID string) (*models.Cart, error) {
+	return nil, repository.ErrGuestCartNotFound
+}
+
+func (r *clearCartRepository) ClearCart(cartID string) error {
+	r.cleared = append(r.cleared, cartID)
+	return nil
+}
+
+func TestClearCartGuestTokenCannotReachUserCart(t *testing.T) {
+	// A user ID is a UUID, so a token signed for it passes verification
+	userID := "9b2f4c1e-6f0a-4d8e-9a51-2c7d3e8f1b60"
+	repo := &clearCartRepository{userCart: &models.Cart{ID: "cart-1", UserID: userID}}
+	signer := NewCartTokenSigner("test-secret")
+	s := &cartService{repo: repo, tokens: signer}
+
+	err := s.ClearCart(CartOwner{CartToken: signer.Sign(userID)})
+	if !errors.Is(err, repository.ErrGuestCartNotFound) {
+		t.Fatalf("err = %v, want ErrGuestCartNotFound", err)
+	}
+	if len(repo.cleared) != 0 {
+		t.Errorf("cleared carts %v, want none", repo.cleared)
+	}
+
+	if err := s.ClearCart(CartOwner{UserID: userID}); err != nil {
+		t.Fatalf("clearing the user's own cart: %v", err)
+	}
+	if len(repo.cleared) != 1 || repo.cleared[0] != "cart-1" {
+		t.Errorf("cleared carts %v, want [cart-1]", repo.cleared)
+	}
+}
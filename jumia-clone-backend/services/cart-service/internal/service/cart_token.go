@@ -0,0 +1,53 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCartToken is returned for a cart token that was not issued by
+// this service
+var ErrInvalidCartToken = errors.New("invalid cart token")
+
+// CartTokenSigner issues and verifies the tokens that identify guest carts.
+// A token is the guest ID followed by an HMAC-SHA256 signature, so guests
+// cannot reach other carts by guessing IDs.
+type CartTokenSigner struct {
+	secret []byte
+}
+
+// NewCartTokenSigner creates a signer with the given secret
+func NewCartTokenSigner(secret string) *CartTokenSigner {
+	return &CartTokenSigner{secret: []byte(secret)}
+}
+
+// Sign returns the cart token for a guest ID
+func (s *CartTokenSigner) Sign(guestID string) string {
+	return guestID + "." + s.signature(guestID)
+}
+
+// Verify returns the guest ID of a cart token
+func (s *CartTokenSigner) Verify(token string) (string, error) {
+	guestID, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidCartToken
+	}
+	if _, err := uuid.Parse(guestID); err != nil {
+		return "", ErrInvalidCartToken
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(guestID))) {
+		return "", ErrInvalidCartToken
+	}
+	return guestID, nil
+}
+
+func (s *CartTokenSigner) signature(guestID string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(guestID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCartTokenSignerRoundTrip(t *testing.T) {
+	signer := NewCartTokenSigner("test-secret")
+	guestID := uuid.New().String()
+
+	token := signer.Sign(guestID)
+	got, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got != guestID {
+		t.Errorf("Verify = %s, want %s", got, guestID)
+	}
+}
+
+func TestCartTokenSignerRejectsTampering(t *testing.T) {
+	signer := NewCartTokenSigner("test-secret")
+	guestID := uuid.New().String()
+	token := signer.Sign(guestID)
+	_, signature, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"other guest ID", uuid.New().String() + "." + signature},
+		{"altered signature", guestID + "." + flipLastChar(signature)},
+		{"missing signature", guestID},
+		{"empty signature", guestID + "."},
+		{"not a UUID", "guest." + signature},
+		{"signed with another secret", NewCartTokenSigner("other-secret").Sign(guestID)},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(tt.token); !errors.Is(err, ErrInvalidCartToken) {
+				t.Errorf("Verify err = %v, want ErrInvalidCartToken", err)
+			}
+		})
+	}
+}
+
+func flipLastChar(s string) string {
+	last := s[len(s)-1]
+	if last == 'A' {
+		return s[:len(s)-1] + "B"
+	}
+	return s[:len(s)-1] + "A"
+}
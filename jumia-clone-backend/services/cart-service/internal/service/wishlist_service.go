@@ -0,0 +1,338 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"jumia-clone-backend/services/cart-service/internal/client"
+	"jumia-clone-backend/services/cart-service/internal/models"
+	"jumia-clone-backend/services/cart-service/internal/repository"
+)
+
+// maxWishlistsPerUser limits how many named lists a user can keep
+const maxWishlistsPerUser = 20
+
+var (
+	ErrWishlistNameTaken  = errors.New("a wishlist with this name already exists")
+	ErrTooManyWishlists   = errors.New("too many wishlists")
+	ErrDefaultWishlist    = errors.New("the default wishlist cannot be deleted")
+	ErrProductNotInCart   = errors.New("product is not in the cart")
+	errWishlistNameLength = errors.New("wishlist name must be between 1 and 100 characters")
+)
+
+// WishlistService manages users' wishlists. A wishlistID of "" refers to the
+// user's default list, which is created on first use.
+type WishlistService interface {
+	CreateWishlist(userID, name string) (*models.Wishlist, error)
+	ListWishlists(userID string) ([]models.Wishlist, error)
+	GetWishlist(userID, wishlistID string) (*models.Wishlist, error)
+	RenameWishlist(userID, wishlistID, name string) (*models.Wishlist, error)
+	DeleteWishlist(userID, wishlistID string) error
+	AddToWishlist(userID, wishlistID, productID string) (*models.Wishlist, error)
+	RemoveFromWishlist(userID, wishlistID, productID string) (*models.Wishlist, error)
+	MoveToCart(userID, wishlistID, productID string, quantity int) (*models.Cart, error)
+	MoveToWishlist(userID, wishlistID, productID string) (*models.Wishlist, error)
+	ListPriceDrops(userID string) ([]models.WishlistItem, error)
+	ClearWishlists(userID string) error
+}
+
+type wishlistService struct {
+	repo     repository.WishlistRepository
+	cartRepo repository.CartRepository
+	carts    CartService
+	products client.ProductClient
+}
+
+func NewWishlistService(repo repository.WishlistRepository, cartRepo repository.CartRepository, carts CartService, products client.ProductClient) WishlistService {
+	return &wishlistService{repo: repo, cartRepo: cartRepo, carts: carts, products: products}
+}
+
+func (s *wishlistService) CreateWishlist(userID, name string) (*models.Wishlist, error) {
+	name, err := wishlistName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The default list always exists first, so a custom list cannot take its name
+	if _, err := s.repo.GetOrCreateDefault(userID); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameFree(userID, name); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWishlistsPerUser {
+		return nil, ErrTooManyWishlists
+	}
+
+	wishlist := &models.Wishlist{UserID: userID, Name: name, Items: []models.WishlistItem{}}
+	if err := s.repo.Create(wishlist); err != nil {
+		return nil, err
+	}
+	return wishlist, nil
+}
+
+// ListWishlists returns all of the user's lists, the default list first
+func (s *wishlistService) ListWishlists(userID string) ([]models.Wishlist, error) {
+	if _, err := s.repo.GetOrCreateDefault(userID); err != nil {
+		return nil, err
+	}
+
+	wishlists, err := s.repo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range wishlists {
+		s.revalidate(&wishlists[i])
+	}
+	return wishlists, nil
+}
+
+func (s *wishlistService) GetWishlist(userID, wishlistID string) (*models.Wishlist, error) {
+	wishlist, err := s.wishlistFor(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.revalidate(wishlist)
+	return wishlist, nil
+}
+
+func (s *wishlistService) RenameWishlist(userID, wishlistID, name string) (*models.Wishlist, error) {
+	name, err := wishlistName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	wishlist, err := s.wishlistFor(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(wishlist.Name, name) {
+		if err := s.checkNameFree(userID, name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Rename(wishlist, name); err != nil {
+		return nil, err
+	}
+	wishlist.Name = name
+
+	s.revalidate(wishlist)
+	return wishlist, nil
+}
+
+func (s *wishlistService) DeleteWishlist(userID, wishlistID string) error {
+	wishlist, err := s.wishlistFor(userID, wishlistID)
+	if err != nil {
+		return err
+	}
+	if wishlist.IsDefault {
+		return ErrDefaultWishlist
+	}
+	return s.repo.Delete(userID, wishlist.ID)
+}
+
+// AddToWishlist adds a product as product service has it now. Adding a
+// product that is already on the list does nothing.
+func (s *wishlistService) AddToWishlist(userID, wishlistID, productID string) (*models.Wishlist, error) {
+	wishlist, err := s.wishlistFor(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.products.GetProduct(productID)
+	if err != nil {
+		if errors.Is(err, client.ErrProductNotFound) {
+			return nil, errors.New("product is not available")
+		}
+		return nil, err
+	}
+
+	err = s.repo.AddItem(&models.WishlistItem{
+		WishlistID:  wishlist.ID,
+		ProductID:   product.Id,
+		ProductName: product.Name,
+		ImageURL:    product.ImageUrl,
+		AddedPrice:  models.RoundPrice(product.FinalPrice),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetWishlist(userID, wishlist.ID)
+}
+
+func (s *wishlistService) RemoveFromWishlist(userID, wishlistID, productID string) (*models.Wishlist, error) {
+	wishlist, err := s.wishlistFor(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RemoveItem(wishlist.ID, productID); err != nil {
+		return nil, err
+	}
+
+	return s.GetWishlist(userID, wishlist.ID)
+}
+
+// MoveToCart adds a wishlist item to the cart and takes it off the list in
+// one transaction. The cart's quantity limits apply; the item stays on the
+// list if they refuse it.
+func (s *wishlistService) MoveToCart(userID, wishlistID, productID string, quantity int) (*models.Cart, error) {
+	wishlist, err := s.wishlistFor(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasWishlistItem(wishlist, productID) {
+		return nil, repository.ErrWishlistItemNotFound
+	}
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	item, err := s.carts.PrepareCartItem(CartOwner{UserID: userID}, productID, quantity)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.MoveToCart(wishlist.ID, item); err != nil {
+		return nil, err
+	}
+
+	return s.carts.GetCart(CartOwner{UserID: userID})
+}
+
+// MoveToWishlist saves a cart item for later: it is put on the wishlist and
+// removed from the cart in one transaction
+func (s *wishlistService) MoveToWishlist(userID, wishlistID, productID string) (*models.Wishlist, error) {
+	wishlist, err := s.wishlistFor(userID, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.cartRepo.GetCart(userID)
+	if err != nil {
+		return nil, err
+	}
+	var cartItem *models.CartItem
+	for i := range cart.Items {
+		if cart.Items[i].ProductID == productID {
+			cartItem = &cart.Items[i]
+		}
+	}
+	if cartItem == nil {
+		return nil, ErrProductNotInCart
+	}
+
+	// Price drops are measured from the product's final price now; if
+	// product service is down, the price the item was added to the cart at
+	// stands in for it
+	item := &models.WishlistItem{
+		WishlistID:  wishlist.ID,
+		ProductID:   cartItem.ProductID,
+		ProductName: cartItem.ProductName,
+		ImageURL:    cartItem.ImageURL,
+		AddedPrice:  cartItem.Price,
+	}
+	if product, err := s.products.GetProduct(productID); err == nil {
+		item.AddedPrice = models.RoundPrice(product.FinalPrice)
+	}
+	if err := s.repo.MoveFromCart(cart.ID, item); err != nil {
+		return nil, err
+	}
+
+	return s.GetWishlist(userID, wishlist.ID)
+}
+
+// ListPriceDrops returns the wishlisted products whose final price fell since
+// they were added, across all of the user's lists
+func (s *wishlistService) ListPriceDrops(userID string) ([]models.WishlistItem, error) {
+	wishlists, err := s.ListWishlists(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	drops := []models.WishlistItem{}
+	seen := make(map[string]bool)
+	for _, wishlist := range wishlists {
+		for _, item := range wishlist.Items {
+			if item.PriceDrop() > 0 && !seen[item.ProductID] {
+				seen[item.ProductID] = true
+				drops = append(drops, item)
+			}
+		}
+	}
+	return drops, nil
+}
+
+// ClearWishlists deletes all of the user's wishlists, for account erasure
+func (s *wishlistService) ClearWishlists(userID string) error {
+	return s.repo.DeleteAllForUser(userID)
+}
+
+// wishlistFor returns the user's wishlist with the given ID, or the default list
+func (s *wishlistService) wishlistFor(userID, wishlistID string) (*models.Wishlist, error) {
+	if userID == "" {
+		return nil, errors.New("user_id is required")
+	}
+	if wishlistID == "" {
+		return s.repo.GetOrCreateDefault(userID)
+	}
+	return s.repo.Get(userID, wishlistID)
+}
+
+func (s *wishlistService) checkNameFree(userID, name string) error {
+	_, err := s.repo.GetByName(userID, name)
+	if err == nil {
+		return ErrWishlistNameTaken
+	}
+	if !errors.Is(err, repository.ErrWishlistNotFound) {
+		return err
+	}
+	return nil
+}
+
+// revalidate fills in each item's current final price and availability from
+// product service
+func (s *wishlistService) revalidate(wishlist *models.Wishlist) {
+	for i := range wishlist.Items {
+		item := &wishlist.Items[i]
+
+		product, err := s.products.GetProduct(item.ProductID)
+		if errors.Is(err, client.ErrProductNotFound) {
+			item.Unavailable = true
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to revalidate product %s in wishlist %s: %v", item.ProductID, wishlist.ID, err)
+			continue
+		}
+
+		item.CurrentPrice = models.RoundPrice(product.FinalPrice)
+		item.Unavailable = !product.InStock
+	}
+}
+
+func hasWishlistItem(wishlist *models.Wishlist, productID string) bool {
+	for _, item := range wishlist.Items {
+		if item.ProductID == productID {
+			return true
+		}
+	}
+	return false
+}
+
+// wishlistName trims a wishlist name and checks its length
+func wishlistName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return "", errWishlistNameLength
+	}
+	return name, nil
+}
@@ -27,9 +27,10 @@ type AddToCartRequest struct {
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	ProductName   string                 `protobuf:"bytes,5,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`                              // Ignored: taken from product service
+	ProductName   string                 `protobuf:"bytes,5,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"` // Ignored: taken from product service
+	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`          // Ignored: taken from product service
+	CartToken     string                 `protobuf:"bytes,7,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,11 +107,19 @@ func (x *AddToCartRequest) GetImageUrl() string {
 	return ""
 }
 
+func (x *AddToCartRequest) GetCartToken() string {
+	if x != nil {
+		return x.CartToken
+	}
+	return ""
+}
+
 type AddToCartResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 	Cart          *CartData              `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	QuantityError *QuantityErrorData     `protobuf:"bytes,4,opt,name=quantity_error,json=quantityError,proto3" json:"quantity_error,omitempty"` // Set when the quantity was refused
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -166,12 +175,20 @@ func (x *AddToCartResponse) GetCart() *CartData {
 	return nil
 }
 
-// Update Cart Item
+func (x *AddToCartResponse) GetQuantityError() *QuantityErrorData {
+	if x != nil {
+		return x.QuantityError
+	}
+	return nil
+}
+
+// Update Cart Item; quantity 0 removes the item
 type UpdateCartItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
 	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CartToken     string                 `protobuf:"bytes,4,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -227,11 +244,19 @@ func (x *UpdateCartItemRequest) GetQuantity() int32 {
 	return 0
 }
 
+func (x *UpdateCartItemRequest) GetCartToken() string {
+	if x != nil {
+		return x.CartToken
+	}
+	return ""
+}
+
 type UpdateCartItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 	Cart          *CartData              `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	QuantityError *QuantityErrorData     `protobuf:"bytes,4,opt,name=quantity_error,json=quantityError,proto3" json:"quantity_error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -287,11 +312,19 @@ func (x *UpdateCartItemResponse) GetCart() *CartData {
 	return nil
 }
 
+func (x *UpdateCartItemResponse) GetQuantityError() *QuantityErrorData {
+	if x != nil {
+		return x.QuantityError
+	}
+	return nil
+}
+
 // Remove from Cart
 type RemoveFromCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CartToken     string                 `protobuf:"bytes,3,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -340,6 +373,13 @@ func (x *RemoveFromCartRequest) GetProductId() string {
 	return ""
 }
 
+func (x *RemoveFromCartRequest) GetCartToken() string {
+	if x != nil {
+		return x.CartToken
+	}
+	return ""
+}
+
 type RemoveFromCartResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -404,6 +444,7 @@ func (x *RemoveFromCartResponse) GetCart() *CartData {
 type GetCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CartToken     string                 `protobuf:"bytes,2,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -445,6 +486,13 @@ func (x *GetCartRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetCartRequest) GetCartToken() string {
+	if x != nil {
+		return x.CartToken
+	}
+	return ""
+}
+
 type GetCartResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Cart          *CartData              `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
@@ -509,6 +557,7 @@ func (x *GetCartResponse) GetMessage() string {
 type ClearCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CartToken     string                 `protobuf:"bytes,2,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -550,6 +599,13 @@ func (x *ClearCartRequest) GetUserId() string {
 	return ""
 }
 
+func (x *ClearCartRequest) GetCartToken() string {
+	if x != nil {
+		return x.CartToken
+	}
+	return ""
+}
+
 type ClearCartResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -602,34 +658,29 @@ func (x *ClearCartResponse) GetMessage() string {
 	return ""
 }
 
-// Cart Data
-type CartData struct {
+// Erase User Cart deletes the user's cart and its reminder records, for
+// account erasure
+type EraseUserCartRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*CartItemData        `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	TotalItems    int32                  `protobuf:"varint,5,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CartData) Reset() {
-	*x = CartData{}
+func (x *EraseUserCartRequest) Reset() {
+	*x = EraseUserCartRequest{}
 	mi := &file_proto_cart_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CartData) String() string {
+func (x *EraseUserCartRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CartData) ProtoMessage() {}
+func (*EraseUserCartRequest) ProtoMessage() {}
 
-func (x *CartData) ProtoReflect() protoreflect.Message {
+func (x *EraseUserCartRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_cart_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -641,89 +692,199 @@ func (x *CartData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CartData.ProtoReflect.Descriptor instead.
-func (*CartData) Descriptor() ([]byte, []int) {
+// Deprecated: Use EraseUserCartRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserCartRequest) Descriptor() ([]byte, []int) {
 	return file_proto_cart_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CartData) GetId() string {
+func (x *EraseUserCartRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *CartData) GetUserId() string {
+type EraseUserCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserCartResponse) Reset() {
+	*x = EraseUserCartResponse{}
+	mi := &file_proto_cart_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserCartResponse) ProtoMessage() {}
+
+func (x *EraseUserCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[11]
 	if x != nil {
-		return x.UserId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserCartResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserCartResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EraseUserCartResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EraseUserCartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *CartData) GetItems() []*CartItemData {
+// Create Guest Cart
+type CreateGuestCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGuestCartRequest) Reset() {
+	*x = CreateGuestCartRequest{}
+	mi := &file_proto_cart_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGuestCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGuestCartRequest) ProtoMessage() {}
+
+func (x *CreateGuestCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[12]
 	if x != nil {
-		return x.Items
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CartData) GetTotalPrice() float64 {
+// Deprecated: Use CreateGuestCartRequest.ProtoReflect.Descriptor instead.
+func (*CreateGuestCartRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{12}
+}
+
+type CreateGuestCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CartToken     string                 `protobuf:"bytes,3,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
+	Cart          *CartData              `protobuf:"bytes,4,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGuestCartResponse) Reset() {
+	*x = CreateGuestCartResponse{}
+	mi := &file_proto_cart_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGuestCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGuestCartResponse) ProtoMessage() {}
+
+func (x *CreateGuestCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[13]
 	if x != nil {
-		return x.TotalPrice
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *CartData) GetTotalItems() int32 {
+// Deprecated: Use CreateGuestCartResponse.ProtoReflect.Descriptor instead.
+func (*CreateGuestCartResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateGuestCartResponse) GetSuccess() bool {
 	if x != nil {
-		return x.TotalItems
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *CartData) GetCreatedAt() string {
+func (x *CreateGuestCartResponse) GetMessage() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Message
 	}
 	return ""
 }
 
-func (x *CartData) GetUpdatedAt() string {
+func (x *CreateGuestCartResponse) GetCartToken() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CartToken
 	}
 	return ""
 }
 
-// Cart Item Data
-type CartItemData struct {
+func (x *CreateGuestCartResponse) GetCart() *CartData {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// Merge Carts moves a guest cart's items into the user's cart on login
+type MergeCartsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	ProductName   string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Subtotal      float64                `protobuf:"fixed64,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CartToken     string                 `protobuf:"bytes,2,opt,name=cart_token,json=cartToken,proto3" json:"cart_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CartItemData) Reset() {
-	*x = CartItemData{}
-	mi := &file_proto_cart_proto_msgTypes[11]
+func (x *MergeCartsRequest) Reset() {
+	*x = MergeCartsRequest{}
+	mi := &file_proto_cart_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CartItemData) String() string {
+func (x *MergeCartsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CartItemData) ProtoMessage() {}
+func (*MergeCartsRequest) ProtoMessage() {}
 
-func (x *CartItemData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_cart_proto_msgTypes[11]
+func (x *MergeCartsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -734,65 +895,1891 @@ func (x *CartItemData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CartItemData.ProtoReflect.Descriptor instead.
-func (*CartItemData) Descriptor() ([]byte, []int) {
-	return file_proto_cart_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use MergeCartsRequest.ProtoReflect.Descriptor instead.
+func (*MergeCartsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *CartItemData) GetId() string {
+func (x *MergeCartsRequest) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *CartItemData) GetProductId() string {
+func (x *MergeCartsRequest) GetCartToken() string {
 	if x != nil {
-		return x.ProductId
+		return x.CartToken
 	}
 	return ""
 }
 
-func (x *CartItemData) GetProductName() string {
+type MergeCartsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Cart          *CartData              `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeCartsResponse) Reset() {
+	*x = MergeCartsResponse{}
+	mi := &file_proto_cart_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeCartsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeCartsResponse) ProtoMessage() {}
+
+func (x *MergeCartsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[15]
 	if x != nil {
-		return x.ProductName
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CartItemData) GetQuantity() int32 {
+// Deprecated: Use MergeCartsResponse.ProtoReflect.Descriptor instead.
+func (*MergeCartsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MergeCartsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Quantity
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *CartItemData) GetPrice() float64 {
+func (x *MergeCartsResponse) GetMessage() string {
 	if x != nil {
-		return x.Price
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *CartItemData) GetSubtotal() float64 {
+func (x *MergeCartsResponse) GetCart() *CartData {
 	if x != nil {
-		return x.Subtotal
+		return x.Cart
 	}
-	return 0
+	return nil
 }
 
-func (x *CartItemData) GetImageUrl() string {
+// Apply Coupon validates a coupon code against the cart and keeps it on the
+// cart until it is removed or the cart is checked out
+type ApplyCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCouponRequest) Reset() {
+	*x = ApplyCouponRequest{}
+	mi := &file_proto_cart_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCouponRequest) ProtoMessage() {}
+
+func (x *ApplyCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[16]
 	if x != nil {
-		return x.ImageUrl
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCouponRequest.ProtoReflect.Descriptor instead.
+func (*ApplyCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ApplyCouponRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-var File_proto_cart_proto protoreflect.FileDescriptor
+func (x *ApplyCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ApplyCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Cart          *CartData              `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	CouponError   *CouponErrorData       `protobuf:"bytes,6,opt,name=coupon_error,json=couponError,proto3" json:"coupon_error,omitempty"` // Set when order service refused the coupon
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCouponResponse) Reset() {
+	*x = ApplyCouponResponse{}
+	mi := &file_proto_cart_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCouponResponse) ProtoMessage() {}
+
+func (x *ApplyCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCouponResponse.ProtoReflect.Descriptor instead.
+func (*ApplyCouponResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ApplyCouponResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApplyCouponResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApplyCouponResponse) GetCart() *CartData {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *ApplyCouponResponse) GetCouponError() *CouponErrorData {
+	if x != nil {
+		return x.CouponError
+	}
+	return nil
+}
+
+// Remove Coupon
+type RemoveCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCouponRequest) Reset() {
+	*x = RemoveCouponRequest{}
+	mi := &file_proto_cart_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCouponRequest) ProtoMessage() {}
+
+func (x *RemoveCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCouponRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCouponRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RemoveCouponRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Cart          *CartData              `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCouponResponse) Reset() {
+	*x = RemoveCouponResponse{}
+	mi := &file_proto_cart_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCouponResponse) ProtoMessage() {}
+
+func (x *RemoveCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCouponResponse.ProtoReflect.Descriptor instead.
+func (*RemoveCouponResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RemoveCouponResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RemoveCouponResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RemoveCouponResponse) GetCart() *CartData {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+// A coupon applied to a cart, checked again whenever the cart is read
+type AppliedCouponData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Discount      float64                `protobuf:"fixed64,3,opt,name=discount,proto3" json:"discount,omitempty"` // Off the items
+	FreeShipping  bool                   `protobuf:"varint,4,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"`
+	Valid         bool                   `protobuf:"varint,5,opt,name=valid,proto3" json:"valid,omitempty"` // False when the coupon no longer applies to the cart
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`  // Why it no longer applies
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppliedCouponData) Reset() {
+	*x = AppliedCouponData{}
+	mi := &file_proto_cart_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedCouponData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedCouponData) ProtoMessage() {}
+
+func (x *AppliedCouponData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedCouponData.ProtoReflect.Descriptor instead.
+func (*AppliedCouponData) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AppliedCouponData) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AppliedCouponData) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AppliedCouponData) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *AppliedCouponData) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *AppliedCouponData) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *AppliedCouponData) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Why a quantity was refused
+type QuantityErrorData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // invalid_quantity, max_per_order_exceeded or insufficient_stock
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MaxQuantity   int32                  `protobuf:"varint,3,opt,name=max_quantity,json=maxQuantity,proto3" json:"max_quantity,omitempty"` // Most of the product the cart may hold
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuantityErrorData) Reset() {
+	*x = QuantityErrorData{}
+	mi := &file_proto_cart_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuantityErrorData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuantityErrorData) ProtoMessage() {}
+
+func (x *QuantityErrorData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuantityErrorData.ProtoReflect.Descriptor instead.
+func (*QuantityErrorData) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *QuantityErrorData) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *QuantityErrorData) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *QuantityErrorData) GetMaxQuantity() int32 {
+	if x != nil {
+		return x.MaxQuantity
+	}
+	return 0
+}
+
+// Cart Data
+type CartData struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId             string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items              []*CartItemData        `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	TotalPrice         float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	TotalItems         int32                  `protobuf:"varint,5,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
+	CreatedAt          string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Guest              bool                   `protobuf:"varint,8,opt,name=guest,proto3" json:"guest,omitempty"` // user_id is empty for guest carts
+	Coupon             *AppliedCouponData     `protobuf:"bytes,9,opt,name=coupon,proto3" json:"coupon,omitempty"`
+	Discount           float64                `protobuf:"fixed64,10,opt,name=discount,proto3" json:"discount,omitempty"`
+	TotalAfterDiscount float64                `protobuf:"fixed64,11,opt,name=total_after_discount,json=totalAfterDiscount,proto3" json:"total_after_discount,omitempty"` // total_price less the coupon discount
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CartData) Reset() {
+	*x = CartData{}
+	mi := &file_proto_cart_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartData) ProtoMessage() {}
+
+func (x *CartData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartData.ProtoReflect.Descriptor instead.
+func (*CartData) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CartData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CartData) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CartData) GetItems() []*CartItemData {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CartData) GetTotalPrice() float64 {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return 0
+}
+
+func (x *CartData) GetTotalItems() int32 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *CartData) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *CartData) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *CartData) GetGuest() bool {
+	if x != nil {
+		return x.Guest
+	}
+	return false
+}
+
+func (x *CartData) GetCoupon() *AppliedCouponData {
+	if x != nil {
+		return x.Coupon
+	}
+	return nil
+}
+
+func (x *CartData) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *CartData) GetTotalAfterDiscount() float64 {
+	if x != nil {
+		return x.TotalAfterDiscount
+	}
+	return 0
+}
+
+// Cart Item Data
+type CartItemData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`       // Current unit price from product service
+	Subtotal      float64                `protobuf:"fixed64,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"` // Zero for unavailable items
+	ImageUrl      string                 `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	AddedPrice    float64                `protobuf:"fixed64,8,opt,name=added_price,json=addedPrice,proto3" json:"added_price,omitempty"` // Unit price when the item was added
+	PriceChanged  bool                   `protobuf:"varint,9,opt,name=price_changed,json=priceChanged,proto3" json:"price_changed,omitempty"`
+	Unavailable   bool                   `protobuf:"varint,10,opt,name=unavailable,proto3" json:"unavailable,omitempty"`                    // Product deleted or out of stock
+	MaxQuantity   int32                  `protobuf:"varint,11,opt,name=max_quantity,json=maxQuantity,proto3" json:"max_quantity,omitempty"` // Most of the product the cart may hold
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartItemData) Reset() {
+	*x = CartItemData{}
+	mi := &file_proto_cart_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartItemData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartItemData) ProtoMessage() {}
+
+func (x *CartItemData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartItemData.ProtoReflect.Descriptor instead.
+func (*CartItemData) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CartItemData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CartItemData) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CartItemData) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *CartItemData) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CartItemData) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CartItemData) GetSubtotal() float64 {
+	if x != nil {
+		return x.Subtotal
+	}
+	return 0
+}
+
+func (x *CartItemData) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *CartItemData) GetAddedPrice() float64 {
+	if x != nil {
+		return x.AddedPrice
+	}
+	return 0
+}
+
+func (x *CartItemData) GetPriceChanged() bool {
+	if x != nil {
+		return x.PriceChanged
+	}
+	return false
+}
+
+func (x *CartItemData) GetUnavailable() bool {
+	if x != nil {
+		return x.Unavailable
+	}
+	return false
+}
+
+func (x *CartItemData) GetMaxQuantity() int32 {
+	if x != nil {
+		return x.MaxQuantity
+	}
+	return 0
+}
+
+// Create Wishlist
+type CreateWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWishlistRequest) Reset() {
+	*x = CreateWishlistRequest{}
+	mi := &file_proto_cart_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWishlistRequest) ProtoMessage() {}
+
+func (x *CreateWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWishlistRequest.ProtoReflect.Descriptor instead.
+func (*CreateWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateWishlistRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Response of the RPCs that return a single wishlist
+type WishlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Wishlist      *WishlistData          `protobuf:"bytes,3,opt,name=wishlist,proto3" json:"wishlist,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WishlistResponse) Reset() {
+	*x = WishlistResponse{}
+	mi := &file_proto_cart_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistResponse) ProtoMessage() {}
+
+func (x *WishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistResponse.ProtoReflect.Descriptor instead.
+func (*WishlistResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *WishlistResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *WishlistResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *WishlistResponse) GetWishlist() *WishlistData {
+	if x != nil {
+		return x.Wishlist
+	}
+	return nil
+}
+
+// List Wishlists; the default list comes first
+type ListWishlistsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWishlistsRequest) Reset() {
+	*x = ListWishlistsRequest{}
+	mi := &file_proto_cart_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWishlistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWishlistsRequest) ProtoMessage() {}
+
+func (x *ListWishlistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWishlistsRequest.ProtoReflect.Descriptor instead.
+func (*ListWishlistsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListWishlistsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListWishlistsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Wishlists     []*WishlistData        `protobuf:"bytes,3,rep,name=wishlists,proto3" json:"wishlists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWishlistsResponse) Reset() {
+	*x = ListWishlistsResponse{}
+	mi := &file_proto_cart_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWishlistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWishlistsResponse) ProtoMessage() {}
+
+func (x *ListWishlistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWishlistsResponse.ProtoReflect.Descriptor instead.
+func (*ListWishlistsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListWishlistsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListWishlistsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListWishlistsResponse) GetWishlists() []*WishlistData {
+	if x != nil {
+		return x.Wishlists
+	}
+	return nil
+}
+
+// Get Wishlist
+type GetWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWishlistRequest) Reset() {
+	*x = GetWishlistRequest{}
+	mi := &file_proto_cart_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWishlistRequest) ProtoMessage() {}
+
+func (x *GetWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWishlistRequest.ProtoReflect.Descriptor instead.
+func (*GetWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetWishlistRequest) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+// Rename Wishlist
+type RenameWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameWishlistRequest) Reset() {
+	*x = RenameWishlistRequest{}
+	mi := &file_proto_cart_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameWishlistRequest) ProtoMessage() {}
+
+func (x *RenameWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameWishlistRequest.ProtoReflect.Descriptor instead.
+func (*RenameWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RenameWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RenameWishlistRequest) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+func (x *RenameWishlistRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Delete Wishlist; the default list cannot be deleted
+type DeleteWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWishlistRequest) Reset() {
+	*x = DeleteWishlistRequest{}
+	mi := &file_proto_cart_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWishlistRequest) ProtoMessage() {}
+
+func (x *DeleteWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWishlistRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteWishlistRequest) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+type DeleteWishlistResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWishlistResponse) Reset() {
+	*x = DeleteWishlistResponse{}
+	mi := &file_proto_cart_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWishlistResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWishlistResponse) ProtoMessage() {}
+
+func (x *DeleteWishlistResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWishlistResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWishlistResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DeleteWishlistResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeleteWishlistResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Add to Wishlist
+type AddToWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddToWishlistRequest) Reset() {
+	*x = AddToWishlistRequest{}
+	mi := &file_proto_cart_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddToWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddToWishlistRequest) ProtoMessage() {}
+
+func (x *AddToWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddToWishlistRequest.ProtoReflect.Descriptor instead.
+func (*AddToWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AddToWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddToWishlistRequest) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+func (x *AddToWishlistRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+// Remove from Wishlist
+type RemoveFromWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFromWishlistRequest) Reset() {
+	*x = RemoveFromWishlistRequest{}
+	mi := &file_proto_cart_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFromWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromWishlistRequest) ProtoMessage() {}
+
+func (x *RemoveFromWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromWishlistRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFromWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemoveFromWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveFromWishlistRequest) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+func (x *RemoveFromWishlistRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+// Move to Cart adds a wishlist item to the user's cart and takes it off the list
+type MoveToCartRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // Defaults to 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToCartRequest) Reset() {
+	*x = MoveToCartRequest{}
+	mi := &file_proto_cart_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartRequest) ProtoMessage() {}
+
+func (x *MoveToCartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartRequest.ProtoReflect.Descriptor instead.
+func (*MoveToCartRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MoveToCartRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *MoveToCartRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type MoveToCartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Cart          *CartData              `protobuf:"bytes,3,opt,name=cart,proto3" json:"cart,omitempty"`
+	QuantityError *QuantityErrorData     `protobuf:"bytes,4,opt,name=quantity_error,json=quantityError,proto3" json:"quantity_error,omitempty"` // Set when the cart refused the quantity
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToCartResponse) Reset() {
+	*x = MoveToCartResponse{}
+	mi := &file_proto_cart_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToCartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToCartResponse) ProtoMessage() {}
+
+func (x *MoveToCartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToCartResponse.ProtoReflect.Descriptor instead.
+func (*MoveToCartResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MoveToCartResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *MoveToCartResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MoveToCartResponse) GetCart() *CartData {
+	if x != nil {
+		return x.Cart
+	}
+	return nil
+}
+
+func (x *MoveToCartResponse) GetQuantityError() *QuantityErrorData {
+	if x != nil {
+		return x.QuantityError
+	}
+	return nil
+}
+
+// Move to Wishlist saves a cart item for later
+type MoveToWishlistRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveToWishlistRequest) Reset() {
+	*x = MoveToWishlistRequest{}
+	mi := &file_proto_cart_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToWishlistRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToWishlistRequest) ProtoMessage() {}
+
+func (x *MoveToWishlistRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToWishlistRequest.ProtoReflect.Descriptor instead.
+func (*MoveToWishlistRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *MoveToWishlistRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MoveToWishlistRequest) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+func (x *MoveToWishlistRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+// List Price Drops returns wishlisted products that got cheaper since they were added
+type ListPriceDropsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceDropsRequest) Reset() {
+	*x = ListPriceDropsRequest{}
+	mi := &file_proto_cart_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceDropsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceDropsRequest) ProtoMessage() {}
+
+func (x *ListPriceDropsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceDropsRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceDropsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListPriceDropsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListPriceDropsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Items         []*WishlistItemData    `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceDropsResponse) Reset() {
+	*x = ListPriceDropsResponse{}
+	mi := &file_proto_cart_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceDropsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceDropsResponse) ProtoMessage() {}
+
+func (x *ListPriceDropsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceDropsResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceDropsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListPriceDropsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListPriceDropsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListPriceDropsResponse) GetItems() []*WishlistItemData {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Clear Wishlists deletes all of a user's wishlists
+type ClearWishlistsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearWishlistsRequest) Reset() {
+	*x = ClearWishlistsRequest{}
+	mi := &file_proto_cart_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearWishlistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearWishlistsRequest) ProtoMessage() {}
+
+func (x *ClearWishlistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearWishlistsRequest.ProtoReflect.Descriptor instead.
+func (*ClearWishlistsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ClearWishlistsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ClearWishlistsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearWishlistsResponse) Reset() {
+	*x = ClearWishlistsResponse{}
+	mi := &file_proto_cart_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearWishlistsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearWishlistsResponse) ProtoMessage() {}
+
+func (x *ClearWishlistsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearWishlistsResponse.ProtoReflect.Descriptor instead.
+func (*ClearWishlistsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ClearWishlistsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ClearWishlistsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Wishlist Data
+type WishlistData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	IsDefault     bool                   `protobuf:"varint,4,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	Items         []*WishlistItemData    `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WishlistData) Reset() {
+	*x = WishlistData{}
+	mi := &file_proto_cart_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistData) ProtoMessage() {}
+
+func (x *WishlistData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistData.ProtoReflect.Descriptor instead.
+func (*WishlistData) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *WishlistData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WishlistData) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WishlistData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WishlistData) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *WishlistData) GetItems() []*WishlistItemData {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *WishlistData) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *WishlistData) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// Wishlist Item Data
+type WishlistItemData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WishlistId    string                 `protobuf:"bytes,2,opt,name=wishlist_id,json=wishlistId,proto3" json:"wishlist_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,5,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	AddedPrice    float64                `protobuf:"fixed64,6,opt,name=added_price,json=addedPrice,proto3" json:"added_price,omitempty"`       // Final price when the item was added
+	CurrentPrice  float64                `protobuf:"fixed64,7,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"` // Final price from product service now
+	PriceDropped  bool                   `protobuf:"varint,8,opt,name=price_dropped,json=priceDropped,proto3" json:"price_dropped,omitempty"`
+	PriceDrop     float64                `protobuf:"fixed64,9,opt,name=price_drop,json=priceDrop,proto3" json:"price_drop,omitempty"` // How much cheaper the product is now
+	Unavailable   bool                   `protobuf:"varint,10,opt,name=unavailable,proto3" json:"unavailable,omitempty"`              // Product deleted or out of stock
+	AddedAt       string                 `protobuf:"bytes,11,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WishlistItemData) Reset() {
+	*x = WishlistItemData{}
+	mi := &file_proto_cart_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WishlistItemData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WishlistItemData) ProtoMessage() {}
+
+func (x *WishlistItemData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cart_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WishlistItemData.ProtoReflect.Descriptor instead.
+func (*WishlistItemData) Descriptor() ([]byte, []int) {
+	return file_proto_cart_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WishlistItemData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WishlistItemData) GetWishlistId() string {
+	if x != nil {
+		return x.WishlistId
+	}
+	return ""
+}
+
+func (x *WishlistItemData) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *WishlistItemData) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *WishlistItemData) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *WishlistItemData) GetAddedPrice() float64 {
+	if x != nil {
+		return x.AddedPrice
+	}
+	return 0
+}
+
+func (x *WishlistItemData) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *WishlistItemData) GetPriceDropped() bool {
+	if x != nil {
+		return x.PriceDropped
+	}
+	return false
+}
+
+func (x *WishlistItemData) GetPriceDrop() float64 {
+	if x != nil {
+		return x.PriceDrop
+	}
+	return 0
+}
+
+func (x *WishlistItemData) GetUnavailable() bool {
+	if x != nil {
+		return x.Unavailable
+	}
+	return false
+}
+
+func (x *WishlistItemData) GetAddedAt() string {
+	if x != nil {
+		return x.AddedAt
+	}
+	return ""
+}
+
+var File_proto_cart_proto protoreflect.FileDescriptor
 
 const file_proto_cart_proto_rawDesc = "" +
 	"\n" +
-	"\x10proto/cart.proto\x12\x04cart\"\xbc\x01\n" +
+	"\x10proto/cart.proto\x12\x04cart\x1a\x11proto/order.proto\"\xdb\x01\n" +
 	"\x10AddToCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
@@ -800,39 +2787,97 @@ const file_proto_cart_proto_rawDesc = "" +
 	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x14\n" +
 	"\x05price\x18\x04 \x01(\x01R\x05price\x12!\n" +
 	"\fproduct_name\x18\x05 \x01(\tR\vproductName\x12\x1b\n" +
-	"\timage_url\x18\x06 \x01(\tR\bimageUrl\"k\n" +
+	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\a \x01(\tR\tcartToken\"\xab\x01\n" +
 	"\x11AddToCartResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\"\n" +
-	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\"k\n" +
+	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\x12>\n" +
+	"\x0equantity_error\x18\x04 \x01(\v2\x17.cart.QuantityErrorDataR\rquantityError\"\x8a\x01\n" +
 	"\x15UpdateCartItemRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x03 \x01(\x05R\bquantity\"p\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x04 \x01(\tR\tcartToken\"\xb0\x01\n" +
 	"\x16UpdateCartItemResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\"\n" +
-	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\"O\n" +
+	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\x12>\n" +
+	"\x0equantity_error\x18\x04 \x01(\v2\x17.cart.QuantityErrorDataR\rquantityError\"n\n" +
 	"\x15RemoveFromCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x02 \x01(\tR\tproductId\"p\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x03 \x01(\tR\tcartToken\"p\n" +
 	"\x16RemoveFromCartResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\"\n" +
-	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\")\n" +
+	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\"H\n" +
 	"\x0eGetCartRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"i\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x02 \x01(\tR\tcartToken\"i\n" +
 	"\x0fGetCartResponse\x12\"\n" +
 	"\x04cart\x18\x01 \x01(\v2\x0e.cart.CartDataR\x04cart\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"+\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"J\n" +
 	"\x10ClearCartRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"G\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x02 \x01(\tR\tcartToken\"G\n" +
 	"\x11ClearCartResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"\xdd\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"/\n" +
+	"\x14EraseUserCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"K\n" +
+	"\x15EraseUserCartResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x18\n" +
+	"\x16CreateGuestCartRequest\"\x90\x01\n" +
+	"\x17CreateGuestCartResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x03 \x01(\tR\tcartToken\x12\"\n" +
+	"\x04cart\x18\x04 \x01(\v2\x0e.cart.CartDataR\x04cart\"K\n" +
+	"\x11MergeCartsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"cart_token\x18\x02 \x01(\tR\tcartToken\"l\n" +
+	"\x12MergeCartsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\"A\n" +
+	"\x12ApplyCouponRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\xb4\x01\n" +
+	"\x13ApplyCouponResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\x129\n" +
+	"\fcoupon_error\x18\x06 \x01(\v2\x16.order.CouponErrorDataR\vcouponErrorJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06\".\n" +
+	"\x13RemoveCouponRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"n\n" +
+	"\x14RemoveCouponResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\"\xa8\x01\n" +
+	"\x11AppliedCouponData\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\bdiscount\x18\x03 \x01(\x01R\bdiscount\x12#\n" +
+	"\rfree_shipping\x18\x04 \x01(\bR\ffreeShipping\x12\x14\n" +
+	"\x05valid\x18\x05 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"i\n" +
+	"\x11QuantityErrorData\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12!\n" +
+	"\fmax_quantity\x18\x03 \x01(\x05R\vmaxQuantity\"\xf2\x02\n" +
 	"\bCartData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12(\n" +
@@ -844,7 +2889,12 @@ const file_proto_cart_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"\xcb\x01\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x14\n" +
+	"\x05guest\x18\b \x01(\bR\x05guest\x12/\n" +
+	"\x06coupon\x18\t \x01(\v2\x17.cart.AppliedCouponDataR\x06coupon\x12\x1a\n" +
+	"\bdiscount\x18\n" +
+	" \x01(\x01R\bdiscount\x120\n" +
+	"\x14total_after_discount\x18\v \x01(\x01R\x12totalAfterDiscount\"\xd6\x02\n" +
 	"\fCartItemData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -853,13 +2903,136 @@ const file_proto_cart_proto_rawDesc = "" +
 	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
 	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
 	"\bsubtotal\x18\x06 \x01(\x01R\bsubtotal\x12\x1b\n" +
-	"\timage_url\x18\a \x01(\tR\bimageUrl2\xdb\x02\n" +
+	"\timage_url\x18\a \x01(\tR\bimageUrl\x12\x1f\n" +
+	"\vadded_price\x18\b \x01(\x01R\n" +
+	"addedPrice\x12#\n" +
+	"\rprice_changed\x18\t \x01(\bR\fpriceChanged\x12 \n" +
+	"\vunavailable\x18\n" +
+	" \x01(\bR\vunavailable\x12!\n" +
+	"\fmax_quantity\x18\v \x01(\x05R\vmaxQuantity\"D\n" +
+	"\x15CreateWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"v\n" +
+	"\x10WishlistResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\bwishlist\x18\x03 \x01(\v2\x12.cart.WishlistDataR\bwishlist\"/\n" +
+	"\x14ListWishlistsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"}\n" +
+	"\x15ListWishlistsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\twishlists\x18\x03 \x03(\v2\x12.cart.WishlistDataR\twishlists\"N\n" +
+	"\x12GetWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\"e\n" +
+	"\x15RenameWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"Q\n" +
+	"\x15DeleteWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\"L\n" +
+	"\x16DeleteWishlistResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"o\n" +
+	"\x14AddToWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\"t\n" +
+	"\x19RemoveFromWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\"\x88\x01\n" +
+	"\x11MoveToCartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"\xac\x01\n" +
+	"\x12MoveToCartResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\"\n" +
+	"\x04cart\x18\x03 \x01(\v2\x0e.cart.CartDataR\x04cart\x12>\n" +
+	"\x0equantity_error\x18\x04 \x01(\v2\x17.cart.QuantityErrorDataR\rquantityError\"p\n" +
+	"\x15MoveToWishlistRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\"0\n" +
+	"\x15ListPriceDropsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"z\n" +
+	"\x16ListPriceDropsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x05items\x18\x03 \x03(\v2\x16.cart.WishlistItemDataR\x05items\"0\n" +
+	"\x15ClearWishlistsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"L\n" +
+	"\x16ClearWishlistsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd6\x01\n" +
+	"\fWishlistData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x04 \x01(\bR\tisDefault\x12,\n" +
+	"\x05items\x18\x05 \x03(\v2\x16.cart.WishlistItemDataR\x05items\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\"\xe9\x02\n" +
+	"\x10WishlistItemData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vwishlist_id\x18\x02 \x01(\tR\n" +
+	"wishlistId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12\x1b\n" +
+	"\timage_url\x18\x05 \x01(\tR\bimageUrl\x12\x1f\n" +
+	"\vadded_price\x18\x06 \x01(\x01R\n" +
+	"addedPrice\x12#\n" +
+	"\rcurrent_price\x18\a \x01(\x01R\fcurrentPrice\x12#\n" +
+	"\rprice_dropped\x18\b \x01(\bR\fpriceDropped\x12\x1d\n" +
+	"\n" +
+	"price_drop\x18\t \x01(\x01R\tpriceDrop\x12 \n" +
+	"\vunavailable\x18\n" +
+	" \x01(\bR\vunavailable\x12\x19\n" +
+	"\badded_at\x18\v \x01(\tR\aaddedAt2\xc1\x05\n" +
 	"\vCartService\x12<\n" +
 	"\tAddToCart\x12\x16.cart.AddToCartRequest\x1a\x17.cart.AddToCartResponse\x12K\n" +
 	"\x0eUpdateCartItem\x12\x1b.cart.UpdateCartItemRequest\x1a\x1c.cart.UpdateCartItemResponse\x12K\n" +
 	"\x0eRemoveFromCart\x12\x1b.cart.RemoveFromCartRequest\x1a\x1c.cart.RemoveFromCartResponse\x126\n" +
 	"\aGetCart\x12\x14.cart.GetCartRequest\x1a\x15.cart.GetCartResponse\x12<\n" +
-	"\tClearCart\x12\x16.cart.ClearCartRequest\x1a\x17.cart.ClearCartResponseB1Z/jumia-clone-backend/services/cart-service/protob\x06proto3"
+	"\tClearCart\x12\x16.cart.ClearCartRequest\x1a\x17.cart.ClearCartResponse\x12H\n" +
+	"\rEraseUserCart\x12\x1a.cart.EraseUserCartRequest\x1a\x1b.cart.EraseUserCartResponse\x12N\n" +
+	"\x0fCreateGuestCart\x12\x1c.cart.CreateGuestCartRequest\x1a\x1d.cart.CreateGuestCartResponse\x12?\n" +
+	"\n" +
+	"MergeCarts\x12\x17.cart.MergeCartsRequest\x1a\x18.cart.MergeCartsResponse\x12B\n" +
+	"\vApplyCoupon\x12\x18.cart.ApplyCouponRequest\x1a\x19.cart.ApplyCouponResponse\x12E\n" +
+	"\fRemoveCoupon\x12\x19.cart.RemoveCouponRequest\x1a\x1a.cart.RemoveCouponResponse2\xad\x06\n" +
+	"\x0fWishlistService\x12E\n" +
+	"\x0eCreateWishlist\x12\x1b.cart.CreateWishlistRequest\x1a\x16.cart.WishlistResponse\x12H\n" +
+	"\rListWishlists\x12\x1a.cart.ListWishlistsRequest\x1a\x1b.cart.ListWishlistsResponse\x12?\n" +
+	"\vGetWishlist\x12\x18.cart.GetWishlistRequest\x1a\x16.cart.WishlistResponse\x12E\n" +
+	"\x0eRenameWishlist\x12\x1b.cart.RenameWishlistRequest\x1a\x16.cart.WishlistResponse\x12K\n" +
+	"\x0eDeleteWishlist\x12\x1b.cart.DeleteWishlistRequest\x1a\x1c.cart.DeleteWishlistResponse\x12C\n" +
+	"\rAddToWishlist\x12\x1a.cart.AddToWishlistRequest\x1a\x16.cart.WishlistResponse\x12M\n" +
+	"\x12RemoveFromWishlist\x12\x1f.cart.RemoveFromWishlistRequest\x1a\x16.cart.WishlistResponse\x12?\n" +
+	"\n" +
+	"MoveToCart\x12\x17.cart.MoveToCartRequest\x1a\x18.cart.MoveToCartResponse\x12E\n" +
+	"\x0eMoveToWishlist\x12\x1b.cart.MoveToWishlistRequest\x1a\x16.cart.WishlistResponse\x12K\n" +
+	"\x0eListPriceDrops\x12\x1b.cart.ListPriceDropsRequest\x1a\x1c.cart.ListPriceDropsResponse\x12K\n" +
+	"\x0eClearWishlists\x12\x1b.cart.ClearWishlistsRequest\x1a\x1c.cart.ClearWishlistsResponseB1Z/jumia-clone-backend/services/cart-service/protob\x06proto3"
 
 var (
 	file_proto_cart_proto_rawDescOnce sync.Once
@@ -873,42 +3046,120 @@ func file_proto_cart_proto_rawDescGZIP() []byte {
 	return file_proto_cart_proto_rawDescData
 }
 
-var file_proto_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_cart_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_proto_cart_proto_goTypes = []any{
-	(*AddToCartRequest)(nil),       // 0: cart.AddToCartRequest
-	(*AddToCartResponse)(nil),      // 1: cart.AddToCartResponse
-	(*UpdateCartItemRequest)(nil),  // 2: cart.UpdateCartItemRequest
-	(*UpdateCartItemResponse)(nil), // 3: cart.UpdateCartItemResponse
-	(*RemoveFromCartRequest)(nil),  // 4: cart.RemoveFromCartRequest
-	(*RemoveFromCartResponse)(nil), // 5: cart.RemoveFromCartResponse
-	(*GetCartRequest)(nil),         // 6: cart.GetCartRequest
-	(*GetCartResponse)(nil),        // 7: cart.GetCartResponse
-	(*ClearCartRequest)(nil),       // 8: cart.ClearCartRequest
-	(*ClearCartResponse)(nil),      // 9: cart.ClearCartResponse
-	(*CartData)(nil),               // 10: cart.CartData
-	(*CartItemData)(nil),           // 11: cart.CartItemData
+	(*AddToCartRequest)(nil),          // 0: cart.AddToCartRequest
+	(*AddToCartResponse)(nil),         // 1: cart.AddToCartResponse
+	(*UpdateCartItemRequest)(nil),     // 2: cart.UpdateCartItemRequest
+	(*UpdateCartItemResponse)(nil),    // 3: cart.UpdateCartItemResponse
+	(*RemoveFromCartRequest)(nil),     // 4: cart.RemoveFromCartRequest
+	(*RemoveFromCartResponse)(nil),    // 5: cart.RemoveFromCartResponse
+	(*GetCartRequest)(nil),            // 6: cart.GetCartRequest
+	(*GetCartResponse)(nil),           // 7: cart.GetCartResponse
+	(*ClearCartRequest)(nil),          // 8: cart.ClearCartRequest
+	(*ClearCartResponse)(nil),         // 9: cart.ClearCartResponse
+	(*EraseUserCartRequest)(nil),      // 10: cart.EraseUserCartRequest
+	(*EraseUserCartResponse)(nil),     // 11: cart.EraseUserCartResponse
+	(*CreateGuestCartRequest)(nil),    // 12: cart.CreateGuestCartRequest
+	(*CreateGuestCartResponse)(nil),   // 13: cart.CreateGuestCartResponse
+	(*MergeCartsRequest)(nil),         // 14: cart.MergeCartsRequest
+	(*MergeCartsResponse)(nil),        // 15: cart.MergeCartsResponse
+	(*ApplyCouponRequest)(nil),        // 16: cart.ApplyCouponRequest
+	(*ApplyCouponResponse)(nil),       // 17: cart.ApplyCouponResponse
+	(*RemoveCouponRequest)(nil),       // 18: cart.RemoveCouponRequest
+	(*RemoveCouponResponse)(nil),      // 19: cart.RemoveCouponResponse
+	(*AppliedCouponData)(nil),         // 20: cart.AppliedCouponData
+	(*QuantityErrorData)(nil),         // 21: cart.QuantityErrorData
+	(*CartData)(nil),                  // 22: cart.CartData
+	(*CartItemData)(nil),              // 23: cart.CartItemData
+	(*CreateWishlistRequest)(nil),     // 24: cart.CreateWishlistRequest
+	(*WishlistResponse)(nil),          // 25: cart.WishlistResponse
+	(*ListWishlistsRequest)(nil),      // 26: cart.ListWishlistsRequest
+	(*ListWishlistsResponse)(nil),     // 27: cart.ListWishlistsResponse
+	(*GetWishlistRequest)(nil),        // 28: cart.GetWishlistRequest
+	(*RenameWishlistRequest)(nil),     // 29: cart.RenameWishlistRequest
+	(*DeleteWishlistRequest)(nil),     // 30: cart.DeleteWishlistRequest
+	(*DeleteWishlistResponse)(nil),    // 31: cart.DeleteWishlistResponse
+	(*AddToWishlistRequest)(nil),      // 32: cart.AddToWishlistRequest
+	(*RemoveFromWishlistRequest)(nil), // 33: cart.RemoveFromWishlistRequest
+	(*MoveToCartRequest)(nil),         // 34: cart.MoveToCartRequest
+	(*MoveToCartResponse)(nil),        // 35: cart.MoveToCartResponse
+	(*MoveToWishlistRequest)(nil),     // 36: cart.MoveToWishlistRequest
+	(*ListPriceDropsRequest)(nil),     // 37: cart.ListPriceDropsRequest
+	(*ListPriceDropsResponse)(nil),    // 38: cart.ListPriceDropsResponse
+	(*ClearWishlistsRequest)(nil),     // 39: cart.ClearWishlistsRequest
+	(*ClearWishlistsResponse)(nil),    // 40: cart.ClearWishlistsResponse
+	(*WishlistData)(nil),              // 41: cart.WishlistData
+	(*WishlistItemData)(nil),          // 42: cart.WishlistItemData
+	(*CouponErrorData)(nil),           // 43: order.CouponErrorData
 }
 var file_proto_cart_proto_depIdxs = []int32{
-	10, // 0: cart.AddToCartResponse.cart:type_name -> cart.CartData
-	10, // 1: cart.UpdateCartItemResponse.cart:type_name -> cart.CartData
-	10, // 2: cart.RemoveFromCartResponse.cart:type_name -> cart.CartData
-	10, // 3: cart.GetCartResponse.cart:type_name -> cart.CartData
-	11, // 4: cart.CartData.items:type_name -> cart.CartItemData
-	0,  // 5: cart.CartService.AddToCart:input_type -> cart.AddToCartRequest
-	2,  // 6: cart.CartService.UpdateCartItem:input_type -> cart.UpdateCartItemRequest
-	4,  // 7: cart.CartService.RemoveFromCart:input_type -> cart.RemoveFromCartRequest
-	6,  // 8: cart.CartService.GetCart:input_type -> cart.GetCartRequest
-	8,  // 9: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
-	1,  // 10: cart.CartService.AddToCart:output_type -> cart.AddToCartResponse
-	3,  // 11: cart.CartService.UpdateCartItem:output_type -> cart.UpdateCartItemResponse
-	5,  // 12: cart.CartService.RemoveFromCart:output_type -> cart.RemoveFromCartResponse
-	7,  // 13: cart.CartService.GetCart:output_type -> cart.GetCartResponse
-	9,  // 14: cart.CartService.ClearCart:output_type -> cart.ClearCartResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	22, // 0: cart.AddToCartResponse.cart:type_name -> cart.CartData
+	21, // 1: cart.AddToCartResponse.quantity_error:type_name -> cart.QuantityErrorData
+	22, // 2: cart.UpdateCartItemResponse.cart:type_name -> cart.CartData
+	21, // 3: cart.UpdateCartItemResponse.quantity_error:type_name -> cart.QuantityErrorData
+	22, // 4: cart.RemoveFromCartResponse.cart:type_name -> cart.CartData
+	22, // 5: cart.GetCartResponse.cart:type_name -> cart.CartData
+	22, // 6: cart.CreateGuestCartResponse.cart:type_name -> cart.CartData
+	22, // 7: cart.MergeCartsResponse.cart:type_name -> cart.CartData
+	22, // 8: cart.ApplyCouponResponse.cart:type_name -> cart.CartData
+	43, // 9: cart.ApplyCouponResponse.coupon_error:type_name -> order.CouponErrorData
+	22, // 10: cart.RemoveCouponResponse.cart:type_name -> cart.CartData
+	23, // 11: cart.CartData.items:type_name -> cart.CartItemData
+	20, // 12: cart.CartData.coupon:type_name -> cart.AppliedCouponData
+	41, // 13: cart.WishlistResponse.wishlist:type_name -> cart.WishlistData
+	41, // 14: cart.ListWishlistsResponse.wishlists:type_name -> cart.WishlistData
+	22, // 15: cart.MoveToCartResponse.cart:type_name -> cart.CartData
+	21, // 16: cart.MoveToCartResponse.quantity_error:type_name -> cart.QuantityErrorData
+	42, // 17: cart.ListPriceDropsResponse.items:type_name -> cart.WishlistItemData
+	42, // 18: cart.WishlistData.items:type_name -> cart.WishlistItemData
+	0,  // 19: cart.CartService.AddToCart:input_type -> cart.AddToCartRequest
+	2,  // 20: cart.CartService.UpdateCartItem:input_type -> cart.UpdateCartItemRequest
+	4,  // 21: cart.CartService.RemoveFromCart:input_type -> cart.RemoveFromCartRequest
+	6,  // 22: cart.CartService.GetCart:input_type -> cart.GetCartRequest
+	8,  // 23: cart.CartService.ClearCart:input_type -> cart.ClearCartRequest
+	10, // 24: cart.CartService.EraseUserCart:input_type -> cart.EraseUserCartRequest
+	12, // 25: cart.CartService.CreateGuestCart:input_type -> cart.CreateGuestCartRequest
+	14, // 26: cart.CartService.MergeCarts:input_type -> cart.MergeCartsRequest
+	16, // 27: cart.CartService.ApplyCoupon:input_type -> cart.ApplyCouponRequest
+	18, // 28: cart.CartService.RemoveCoupon:input_type -> cart.RemoveCouponRequest
+	24, // 29: cart.WishlistService.CreateWishlist:input_type -> cart.CreateWishlistRequest
+	26, // 30: cart.WishlistService.ListWishlists:input_type -> cart.ListWishlistsRequest
+	28, // 31: cart.WishlistService.GetWishlist:input_type -> cart.GetWishlistRequest
+	29, // 32: cart.WishlistService.RenameWishlist:input_type -> cart.RenameWishlistRequest
+	30, // 33: cart.WishlistService.DeleteWishlist:input_type -> cart.DeleteWishlistRequest
+	32, // 34: cart.WishlistService.AddToWishlist:input_type -> cart.AddToWishlistRequest
+	33, // 35: cart.WishlistService.RemoveFromWishlist:input_type -> cart.RemoveFromWishlistRequest
+	34, // 36: cart.WishlistService.MoveToCart:input_type -> cart.MoveToCartRequest
+	36, // 37: cart.WishlistService.MoveToWishlist:input_type -> cart.MoveToWishlistRequest
+	37, // 38: cart.WishlistService.ListPriceDrops:input_type -> cart.ListPriceDropsRequest
+	39, // 39: cart.WishlistService.ClearWishlists:input_type -> cart.ClearWishlistsRequest
+	1,  // 40: cart.CartService.AddToCart:output_type -> cart.AddToCartResponse
+	3,  // 41: cart.CartService.UpdateCartItem:output_type -> cart.UpdateCartItemResponse
+	5,  // 42: cart.CartService.RemoveFromCart:output_type -> cart.RemoveFromCartResponse
+	7,  // 43: cart.CartService.GetCart:output_type -> cart.GetCartResponse
+	9,  // 44: cart.CartService.ClearCart:output_type -> cart.ClearCartResponse
+	11, // 45: cart.CartService.EraseUserCart:output_type -> cart.EraseUserCartResponse
+	13, // 46: cart.CartService.CreateGuestCart:output_type -> cart.CreateGuestCartResponse
+	15, // 47: cart.CartService.MergeCarts:output_type -> cart.MergeCartsResponse
+	17, // 48: cart.CartService.ApplyCoupon:output_type -> cart.ApplyCouponResponse
+	19, // 49: cart.CartService.RemoveCoupon:output_type -> cart.RemoveCouponResponse
+	25, // 50: cart.WishlistService.CreateWishlist:output_type -> cart.WishlistResponse
+	27, // 51: cart.WishlistService.ListWishlists:output_type -> cart.ListWishlistsResponse
+	25, // 52: cart.WishlistService.GetWishlist:output_type -> cart.WishlistResponse
+	25, // 53: cart.WishlistService.RenameWishlist:output_type -> cart.WishlistResponse
+	31, // 54: cart.WishlistService.DeleteWishlist:output_type -> cart.DeleteWishlistResponse
+	25, // 55: cart.WishlistService.AddToWishlist:output_type -> cart.WishlistResponse
+	25, // 56: cart.WishlistService.RemoveFromWishlist:output_type -> cart.WishlistResponse
+	35, // 57: cart.WishlistService.MoveToCart:output_type -> cart.MoveToCartResponse
+	25, // 58: cart.WishlistService.MoveToWishlist:output_type -> cart.WishlistResponse
+	38, // 59: cart.WishlistService.ListPriceDrops:output_type -> cart.ListPriceDropsResponse
+	40, // 60: cart.WishlistService.ClearWishlists:output_type -> cart.ClearWishlistsResponse
+	40, // [40:61] is the sub-list for method output_type
+	19, // [19:40] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_proto_cart_proto_init() }
@@ -916,15 +3167,16 @@ func file_proto_cart_proto_init() {
 	if File_proto_cart_proto != nil {
 		return
 	}
+	file_proto_order_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_cart_proto_rawDesc), len(file_proto_cart_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   43,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_cart_proto_goTypes,
 		DependencyIndexes: file_proto_cart_proto_depIdxs,
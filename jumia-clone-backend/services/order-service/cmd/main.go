@@ -3,11 +3,14 @@ package main
 import (
 	"log"
 	"net"
+	"os"
+	"strconv"
 
 	"google.golang.org/grpc"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"jumia-clone-backend/services/order-service/internal/client"
 	"jumia-clone-backend/services/order-service/internal/handler"
 	"jumia-clone-backend/services/order-service/internal/models"
 	"jumia-clone-backend/services/order-service/internal/repository"
@@ -24,15 +27,54 @@ func main() {
 	}
 
 	// Auto-migrate the schema
-	if err := db.AutoMigrate(&models.Order{}, &models.OrderItem{}); err != nil {
+	if err := db.AutoMigrate(&models.Order{}, &models.OrderItem{}, &models.OrderStatusEvent{}, &models.Coupon{}, &models.CouponRedemption{}); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
 	log.Println("Database connected and migrated successfully")
 
+	// Connect to product service for authoritative prices and stock
+	productServiceAddr := getEnv("PRODUCT_SERVICE_ADDR", "localhost:50052")
+	productClient, err := client.NewProductClient(productServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to product service: %v", err)
+	}
+	defer productClient.Close()
+
+	// Connect to cart service for checkout
+	cartServiceAddr := getEnv("CART_SERVICE_ADDR", "localhost:50053")
+	cartClient, err := client.NewCartClient(cartServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to cart service: %v", err)
+	}
+	defer cartClient.Close()
+
+	// Connect to user service for saved shipping addresses
+	userServiceAddr := getEnv("USER_SERVICE_ADDR", "localhost:50051")
+	userClient, err := client.NewUserClient(userServiceAddr)
+	if err != nil {
+		log.Fatalf("Failed to connect to user service: %v", err)
+	}
+	defer userClient.Close()
+
+	// Flat shipping fee charged on every order, waived by free shipping coupons
+	shippingFee, err := strconv.ParseFloat(getEnv("SHIPPING_FEE", "0"), 64)
+	if err != nil || shippingFee < 0 {
+		log.Fatalf("Invalid SHIPPING_FEE: %q", os.Getenv("SHIPPING_FEE"))
+	}
+
+	// Units of one product an order may hold, unless the product sets its
+	// own max_per_order; must match cart service's MAX_QUANTITY_PER_PRODUCT
+	maxPerProduct, err := strconv.Atoi(getEnv("MAX_QUANTITY_PER_PRODUCT", "10"))
+	if err != nil || maxPerProduct < 0 {
+		log.Fatalf("Invalid MAX_QUANTITY_PER_PRODUCT: %q", os.Getenv("MAX_QUANTITY_PER_PRODUCT"))
+	}
+
 	// Initialize layers
+	couponService := service.NewCouponService(repository.NewCouponRepository(db), productClient)
+	couponHandler := handler.NewCouponHandler(couponService)
 	orderRepo := repository.NewOrderRepository(db)
-	orderService := service.NewOrderService(orderRepo)
+	orderService := service.NewOrderService(orderRepo, couponService, productClient, cartClient, userClient, models.RoundPrice(shippingFee), maxPerProduct)
 	orderHandler := handler.NewOrderHandler(orderService)
 
 	// Set up gRPC server
@@ -43,9 +85,19 @@ func main() {
 
 	s := grpc.NewServer()
 	pb.RegisterOrderServiceServer(s, orderHandler)
+	pb.RegisterCouponServiceServer(s, couponHandler)
 
 	log.Println("Order service is running on port 50054...")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
+
+// getEnv gets environment variable or returns default value
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "jumia-clone-backend/services/order-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// CartClient reads and clears user carts in cart service
+type CartClient interface {
+	GetCart(userID string) (*pb.CartData, error)
+	ClearCart(userID string) error
+	Close() error
+}
+
+type cartClient struct {
+	conn   *grpc.ClientConn
+	client pb.CartServiceClient
+}
+
+// NewCartClient connects to cart service at the given address
+func NewCartClient(addr string) (CartClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &cartClient{
+		conn:   conn,
+		client: pb.NewCartServiceClient(conn),
+	}, nil
+}
+
+// GetCart returns the user's cart; a user without a cart gets an empty one
+func (c *cartClient) GetCart(userID string) (*pb.CartData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success || resp.Cart == nil {
+		return nil, errors.New(resp.Message)
+	}
+
+	return resp.Cart, nil
+}
+
+// ClearCart removes every item from the user's cart
+func (c *cartClient) ClearCart(userID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ClearCart(ctx, &pb.ClearCartRequest{UserId: userID})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Message)
+	}
+	return nil
+}
+
+func (c *cartClient) Close() error {
+	return c.conn.Close()
+}
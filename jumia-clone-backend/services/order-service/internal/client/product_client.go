@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "jumia-clone-backend/services/order-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ProductClient looks up authoritative product data in product service
+type ProductClient interface {
+	GetProduct(productID string) (*pb.ProductData, error)
+	ReserveStock(quantities map[string]int) error
+	ReleaseStock(quantities map[string]int) error
+	Close() error
+}
+
+type productClient struct {
+	conn   *grpc.ClientConn
+	client pb.ProductServiceClient
+}
+
+// NewProductClient connects to product service at the given address
+func NewProductClient(addr string) (ProductClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &productClient{
+		conn:   conn,
+		client: pb.NewProductServiceClient(conn),
+	}, nil
+}
+
+// GetProduct returns the product if it exists and is active
+func (c *productClient) GetProduct(productID string) (*pb.ProductData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetProduct(ctx, &pb.GetProductRequest{Id: productID})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success || resp.Product == nil {
+		return nil, errors.New(resp.Message)
+	}
+
+	return resp.Product, nil
+}
+
+// ReserveStock decrements stock for all products, or for none if any is short
+func (c *productClient) ReserveStock(quantities map[string]int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ReserveStock(ctx, &pb.ReserveStockRequest{Items: toStockItems(quantities)})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Message)
+	}
+	return nil
+}
+
+// ReleaseStock returns reserved units to stock
+func (c *productClient) ReleaseStock(quantities map[string]int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ReleaseStock(ctx, &pb.ReleaseStockRequest{Items: toStockItems(quantities)})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Message)
+	}
+	return nil
+}
+
+func (c *productClient) Close() error {
+	return c.conn.Close()
+}
+
+func toStockItems(quantities map[string]int) []*pb.StockItem {
+	items := make([]*pb.StockItem, 0, len(quantities))
+	for productID, quantity := range quantities {
+		items = append(items, &pb.StockItem{ProductId: productID, Quantity: int32(quantity)})
+	}
+	return items
+}
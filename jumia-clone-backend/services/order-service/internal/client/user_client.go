@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "jumia-clone-backend/services/order-service/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// UserClient looks up saved shipping addresses in user service
+type UserClient interface {
+	GetAddress(addressID, userID string) (*pb.AddressData, error)
+	Close() error
+}
+
+type userClient struct {
+	conn   *grpc.ClientConn
+	client pb.UserServiceClient
+}
+
+// NewUserClient connects to user service at the given address
+func NewUserClient(addr string) (UserClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &userClient{
+		conn:   conn,
+		client: pb.NewUserServiceClient(conn),
+	}, nil
+}
+
+// GetAddress returns the address if it belongs to the user
+func (c *userClient) GetAddress(addressID, userID string) (*pb.AddressData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetAddress(ctx, &pb.GetAddressRequest{AddressId: addressID, UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success || resp.Address == nil {
+		return nil, errors.New(resp.Message)
+	}
+
+	return resp.Address, nil
+}
+
+func (c *userClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"jumia-clone-backend/services/order-service/internal/models"
+	"jumia-clone-backend/services/order-service/internal/repository"
+	"jumia-clone-backend/services/order-service/internal/service"
+	pb "jumia-clone-backend/services/order-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CouponServiceHandler struct {
+	pb.UnimplementedCouponServiceServer
+	couponService service.CouponService
+}
+
+func NewCouponHandler(couponService service.CouponService) *CouponServiceHandler {
+	return &CouponServiceHandler{
+		couponService: couponService,
+	}
+}
+
+func (h *CouponServiceHandler) CreateCoupon(ctx context.Context, req *pb.CreateCouponRequest) (*pb.CouponResponse, error) {
+	input, err := couponInput(req.Coupon)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	coupon, err := h.couponService.CreateCoupon(input)
+	if err != nil {
+		return couponError(err)
+	}
+
+	return &pb.CouponResponse{
+		Success: true,
+		Message: "Coupon created successfully",
+		Coupon:  convertToCouponData(coupon),
+	}, nil
+}
+
+func (h *CouponServiceHandler) UpdateCoupon(ctx context.Context, req *pb.UpdateCouponRequest) (*pb.CouponResponse, error) {
+	input, err := couponInput(req.Coupon)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	coupon, err := h.couponService.UpdateCoupon(req.Id, input)
+	if err != nil {
+		return couponError(err)
+	}
+
+	return &pb.CouponResponse{
+		Success: true,
+		Message: "Coupon updated successfully",
+		Coupon:  convertToCouponData(coupon),
+	}, nil
+}
+
+func (h *CouponServiceHandler) GetCoupon(ctx context.Context, req *pb.GetCouponRequest) (*pb.CouponResponse, error) {
+	coupon, err := h.couponService.GetCoupon(req.Id)
+	if err != nil {
+		return couponError(err)
+	}
+
+	return &pb.CouponResponse{
+		Success: true,
+		Message: "Coupon retrieved successfully",
+		Coupon:  convertToCouponData(coupon),
+	}, nil
+}
+
+func (h *CouponServiceHandler) ListCoupons(ctx context.Context, req *pb.ListCouponsRequest) (*pb.ListCouponsResponse, error) {
+	coupons, total, err := h.couponService.ListCoupons(int(req.Page), int(req.PageSize))
+	if err != nil {
+		return &pb.ListCouponsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	couponDataList := make([]*pb.CouponData, 0, len(coupons))
+	for i := range coupons {
+		couponDataList = append(couponDataList, convertToCouponData(&coupons[i]))
+	}
+
+	return &pb.ListCouponsResponse{
+		Success: true,
+		Message: "Coupons retrieved successfully",
+		Coupons: couponDataList,
+		Total:   int32(total),
+	}, nil
+}
+
+func (h *CouponServiceHandler) ValidateCoupon(ctx context.Context, req *pb.ValidateCouponRequest) (*pb.ValidateCouponResponse, error) {
+	items := make([]service.OrderItemInput, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, service.OrderItemInput{
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
+		})
+	}
+
+	quote, err := h.couponService.ValidateCoupon(req.Code, req.UserId, items)
+	if err != nil {
+		return &pb.ValidateCouponResponse{
+			Success:     false,
+			Message:     err.Error(),
+			CouponError: convertToCouponErrorData(err),
+		}, nil
+	}
+
+	return &pb.ValidateCouponResponse{
+		Success: true,
+		Message: "Coupon applies",
+		Quote: &pb.CouponQuoteData{
+			Code:             quote.Code,
+			Type:             quote.Type,
+			Discount:         quote.Discount,
+			FreeShipping:     quote.FreeShipping,
+			EligibleSubtotal: quote.EligibleSubtotal,
+		},
+	}, nil
+}
+
+// couponError answers a failed RPC that returns a single coupon
+func couponError(err error) (*pb.CouponResponse, error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidCoupon):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrCouponCodeTaken):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repository.ErrCouponNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &pb.CouponResponse{
+		Success: false,
+		Message: err.Error(),
+	}, nil
+}
+
+// couponInput reads coupon terms from a request
+func couponInput(spec *pb.CouponSpec) (service.CouponInput, error) {
+	if spec == nil {
+		return service.CouponInput{}, errors.New("coupon is required")
+	}
+
+	startsAt, err := parseOptionalTime("starts_at", spec.StartsAt)
+	if err != nil {
+		return service.CouponInput{}, err
+	}
+	expiresAt, err := parseOptionalTime("expires_at", spec.ExpiresAt)
+	if err != nil {
+		return service.CouponInput{}, err
+	}
+
+	return service.CouponInput{
+		Code:         spec.Code,
+		Description:  spec.Description,
+		Type:         spec.Type,
+		Value:        spec.Value,
+		MinBasket:    spec.MinBasket,
+		UsageLimit:   int(spec.UsageLimit),
+		PerUserLimit: int(spec.PerUserLimit),
+		StartsAt:     startsAt,
+		ExpiresAt:    expiresAt,
+		Categories:   spec.Categories,
+		Brands:       spec.Brands,
+		Active:       spec.Active,
+	}, nil
+}
+
+// parseOptionalTime parses an RFC 3339 time; an empty value is no time
+func parseOptionalTime(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time", field)
+	}
+	return &t, nil
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func convertToCouponData(coupon *models.Coupon) *pb.CouponData {
+	return &pb.CouponData{
+		Id:            coupon.ID,
+		Code:          coupon.Code,
+		Description:   coupon.Description,
+		Type:          coupon.Type,
+		Value:         coupon.Value,
+		MinBasket:     coupon.MinBasket,
+		UsageLimit:    int32(coupon.UsageLimit),
+		PerUserLimit:  int32(coupon.PerUserLimit),
+		TimesRedeemed: int32(coupon.TimesRedeemed),
+		StartsAt:      formatOptionalTime(coupon.StartsAt),
+		ExpiresAt:     formatOptionalTime(coupon.ExpiresAt),
+		Categories:    coupon.CategoryList(),
+		Brands:        coupon.BrandList(),
+		Active:        coupon.Active,
+		CreatedAt:     coupon.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     coupon.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// convertToCouponErrorData describes a refused coupon, or returns nil for other errors
+func convertToCouponErrorData(err error) *pb.CouponErrorData {
+	var couponErr *service.CouponError
+	if !errors.As(err, &couponErr) {
+		return nil
+	}
+	return &pb.CouponErrorData{
+		Code:      couponErr.Code,
+		MinBasket: couponErr.MinBasket,
+	}
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"jumia-clone-backend/services/order-service/internal/models"
 	"jumia-clone-backend/services/order-service/internal/service"
 	pb "jumia-clone-backend/services/order-service/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type OrderServiceHandler struct {
@@ -24,18 +28,17 @@ func (h *OrderServiceHandler) CreateOrder(ctx context.Context, req *pb.CreateOrd
 	items := make([]service.OrderItemInput, 0, len(req.Items))
 	for _, item := range req.Items {
 		items = append(items, service.OrderItemInput{
-			ProductID:   item.ProductId,
-			ProductName: item.ProductName,
-			Quantity:    int(item.Quantity),
-			Price:       item.Price,
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
 		})
 	}
 
-	order, err := h.orderService.CreateOrder(req.UserId, req.ShippingAddress, req.PaymentMethod, items)
+	order, err := h.orderService.CreateOrder(req.UserId, req.AddressId, req.ShippingAddress, req.PaymentMethod, req.CouponCode, items)
 	if err != nil {
 		return &pb.CreateOrderResponse{
-			Success: false,
-			Message: err.Error(),
+			Success:     false,
+			Message:     err.Error(),
+			CouponError: convertToCouponErrorData(err),
 		}, nil
 	}
 
@@ -94,7 +97,13 @@ func (h *OrderServiceHandler) ListOrders(ctx context.Context, req *pb.ListOrders
 }
 
 func (h *OrderServiceHandler) UpdateOrderStatus(ctx context.Context, req *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
-	order, err := h.orderService.UpdateOrderStatus(req.OrderId, req.Status)
+	order, err := h.orderService.UpdateOrderStatus(req.OrderId, req.Status, req.Actor, req.Reason)
+	if errors.Is(err, service.ErrInvalidStatus) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, service.ErrInvalidTransition) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 	if err != nil {
 		return &pb.UpdateOrderStatusResponse{
 			Success: false,
@@ -110,7 +119,7 @@ func (h *OrderServiceHandler) UpdateOrderStatus(ctx context.Context, req *pb.Upd
 }
 
 func (h *OrderServiceHandler) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
-	err := h.orderService.CancelOrder(req.OrderId, req.UserId)
+	err := h.orderService.CancelOrder(req.OrderId, req.UserId, req.Reason)
 	if err != nil {
 		return &pb.CancelOrderResponse{
 			Success: false,
@@ -124,6 +133,59 @@ func (h *OrderServiceHandler) CancelOrder(ctx context.Context, req *pb.CancelOrd
 	}, nil
 }
 
+func (h *OrderServiceHandler) Checkout(ctx context.Context, req *pb.CheckoutRequest) (*pb.CheckoutResponse, error) {
+	order, err := h.orderService.Checkout(req.UserId, req.AddressId, req.ShippingAddress, req.PaymentMethod)
+	if err != nil {
+		return &pb.CheckoutResponse{
+			Success:     false,
+			Message:     err.Error(),
+			CouponError: convertToCouponErrorData(err),
+		}, nil
+	}
+
+	return &pb.CheckoutResponse{
+		Success: true,
+		Message: "Checkout completed successfully",
+		Order:   convertToOrderData(order),
+	}, nil
+}
+
+func (h *OrderServiceHandler) GetOrderHistory(ctx context.Context, req *pb.GetOrderHistoryRequest) (*pb.GetOrderHistoryResponse, error) {
+	events, err := h.orderService.GetOrderHistory(req.OrderId)
+	if err != nil {
+		return &pb.GetOrderHistoryResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.GetOrderHistoryResponse{
+		Success: true,
+		Message: "Order history retrieved successfully",
+		History: convertToStatusEventData(events),
+	}, nil
+}
+
+// AnonymizeUserOrders erases the shipping details on a user's orders
+func (h *OrderServiceHandler) AnonymizeUserOrders(ctx context.Context, req *pb.AnonymizeUserOrdersRequest) (*pb.AnonymizeUserOrdersResponse, error) {
+	count, err := h.orderService.AnonymizeUserOrders(req.UserId)
+	if errors.Is(err, service.ErrOpenOrders) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return &pb.AnonymizeUserOrdersResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.AnonymizeUserOrdersResponse{
+		Success:          true,
+		Message:          "Orders anonymized successfully",
+		OrdersAnonymized: int32(count),
+	}, nil
+}
+
 func convertToOrderData(order *models.Order) *pb.OrderData {
 	items := make([]*pb.OrderItemData, 0, len(order.Items))
 	for _, item := range order.Items {
@@ -137,15 +199,54 @@ func convertToOrderData(order *models.Order) *pb.OrderData {
 		})
 	}
 
+	var anonymizedAt string
+	if order.AnonymizedAt != nil {
+		anonymizedAt = order.AnonymizedAt.Format(time.RFC3339)
+	}
+
 	return &pb.OrderData{
 		Id:              order.ID,
 		UserId:          order.UserID,
 		Items:           items,
 		Status:          order.Status,
 		TotalPrice:      order.TotalPrice,
+		Subtotal:        orderSubtotal(order),
+		Discount:        order.Discount,
+		ShippingFee:     order.ShippingFee,
+		CouponCode:      order.CouponCode,
 		ShippingAddress: order.ShippingAddress,
 		PaymentMethod:   order.PaymentMethod,
 		CreatedAt:       order.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:       order.UpdatedAt.Format(time.RFC3339),
+		History:         convertToStatusEventData(order.History),
+		AddressId:       order.AddressID,
+		RecipientName:   order.RecipientName,
+		RecipientPhone:  order.RecipientPhone,
+		AnonymizedAt:    anonymizedAt,
+	}
+}
+
+// orderSubtotal returns the sum of the order's items. Orders placed before
+// subtotals were stored have none, but their total is the sum of the items.
+func orderSubtotal(order *models.Order) float64 {
+	if order.Subtotal == 0 {
+		return models.RoundPrice(order.CalculateTotalPrice())
+	}
+	return order.Subtotal
+}
+
+func convertToStatusEventData(events []models.OrderStatusEvent) []*pb.OrderStatusEventData {
+	history := make([]*pb.OrderStatusEventData, 0, len(events))
+	for _, event := range events {
+		history = append(history, &pb.OrderStatusEventData{
+			Id:         event.ID,
+			OrderId:    event.OrderID,
+			FromStatus: event.FromStatus,
+			ToStatus:   event.ToStatus,
+			Actor:      event.Actor,
+			Reason:     event.Reason,
+			CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+		})
 	}
+	return history
 }
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Coupon types
+const (
+	CouponPercentage   = "percentage"    // Value percent off the eligible items
+	CouponFixed        = "fixed"         // Value off the eligible items
+	CouponFreeShipping = "free_shipping" // Waives the shipping fee
+)
+
+// Coupon is a promo code customers can apply to their cart. Zero limits and
+// minimum basket mean none; empty categories and brands mean every product
+// is eligible.
+type Coupon struct {
+	ID            string     `gorm:"type:uuid;primary_key" json:"id"`
+	Code          string     `gorm:"type:varchar(50);uniqueIndex;not null" json:"code"` // Stored upper case
+	Description   string     `gorm:"type:text" json:"description"`
+	Type          string     `gorm:"type:varchar(20);not null" json:"type"`
+	Value         float64    `gorm:"type:decimal(10,2);not null;default:0" json:"value"`
+	MinBasket     float64    `gorm:"type:decimal(10,2);not null;default:0" json:"min_basket"`
+	UsageLimit    int        `gorm:"not null;default:0" json:"usage_limit"`    // Redemptions across all users
+	PerUserLimit  int        `gorm:"not null;default:0" json:"per_user_limit"` // Redemptions per user
+	TimesRedeemed int        `gorm:"not null;default:0" json:"times_redeemed"`
+	StartsAt      *time.Time `json:"starts_at"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	Categories    string     `gorm:"type:text" json:"categories"` // Comma separated
+	Brands        string     `gorm:"type:text" json:"brands"`     // Comma separated
+	Active        bool       `gorm:"not null;default:true" json:"active"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// CouponRedemption records a coupon used on an order. It is written in the
+// same transaction as the order.
+type CouponRedemption struct {
+	ID        string    `gorm:"type:uuid;primary_key" json:"id"`
+	CouponID  string    `gorm:"type:uuid;not null;index:idx_coupon_redemptions_user" json:"coupon_id"`
+	UserID    string    `gorm:"type:uuid;not null;index:idx_coupon_redemptions_user" json:"user_id"`
+	OrderID   string    `gorm:"type:uuid;not null;uniqueIndex" json:"order_id"`
+	Discount  float64   `gorm:"type:decimal(10,2);not null" json:"discount"` // Including waived shipping
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (Coupon) TableName() string {
+	return "coupons"
+}
+
+func (CouponRedemption) TableName() string {
+	return "coupon_redemptions"
+}
+
+func (c *Coupon) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (cr *CouponRedemption) BeforeCreate(tx *gorm.DB) error {
+	if cr.ID == "" {
+		cr.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsValidCouponType reports whether couponType is one of the coupon types
+func IsValidCouponType(couponType string) bool {
+	switch couponType {
+	case CouponPercentage, CouponFixed, CouponFreeShipping:
+		return true
+	}
+	return false
+}
+
+// NormalizeCouponCode returns a code as it is stored, so codes match
+// regardless of case
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CategoryList returns the categories the coupon is restricted to
+func (c *Coupon) CategoryList() []string {
+	return splitList(c.Categories)
+}
+
+// BrandList returns the brands the coupon is restricted to
+func (c *Coupon) BrandList() []string {
+	return splitList(c.Brands)
+}
+
+// Applies reports whether a product of the given category and brand is
+// eligible for the coupon
+func (c *Coupon) Applies(category, brand string) bool {
+	return matchesList(c.CategoryList(), category) && matchesList(c.BrandList(), brand)
+}
+
+// JoinList stores a list of categories or brands
+func JoinList(values []string) string {
+	cleaned := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			cleaned = append(cleaned, value)
+		}
+	}
+	return strings.Join(cleaned, ",")
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
+
+// matchesList reports whether value is in list, ignoring case; an empty
+// list matches everything
+func matchesList(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if strings.EqualFold(item, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoundPrice rounds a price to cents, as prices are stored
+func RoundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
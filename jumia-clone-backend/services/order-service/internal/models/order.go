@@ -7,16 +7,54 @@ import (
 	"gorm.io/gorm"
 )
 
+// Order statuses
+const (
+	StatusPending    = "pending"
+	StatusConfirmed  = "confirmed"
+	StatusProcessing = "processing"
+	StatusShipped    = "shipped"
+	StatusDelivered  = "delivered"
+	StatusCancelled  = "cancelled"
+	StatusRefunded   = "refunded"
+	StatusReturned   = "returned"
+)
+
+// statusTransitions lists the statuses an order may move to from each status.
+// Refunded is terminal.
+var statusTransitions = map[string][]string{
+	StatusPending:    {StatusConfirmed, StatusCancelled},
+	StatusConfirmed:  {StatusProcessing, StatusCancelled},
+	StatusProcessing: {StatusShipped, StatusCancelled},
+	StatusShipped:    {StatusDelivered, StatusReturned},
+	StatusDelivered:  {StatusReturned, StatusRefunded},
+	StatusReturned:   {StatusRefunded},
+	StatusCancelled:  {StatusRefunded},
+	StatusRefunded:   {},
+}
+
+// OpenStatuses are the statuses of orders that are still being fulfilled and
+// so still need the customer's shipping details
+var OpenStatuses = []string{StatusPending, StatusConfirmed, StatusProcessing, StatusShipped}
+
 type Order struct {
-	ID              string      `gorm:"type:uuid;primary_key" json:"id"`
-	UserID          string      `gorm:"type:uuid;not null;index" json:"user_id"`
-	Items           []OrderItem `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"items"`
-	Status          string      `gorm:"type:varchar(50);not null;default:'pending'" json:"status"`
-	TotalPrice      float64     `gorm:"type:decimal(10,2);not null" json:"total_price"`
-	ShippingAddress string      `gorm:"type:text" json:"shipping_address"`
-	PaymentMethod   string      `gorm:"type:varchar(50)" json:"payment_method"`
-	CreatedAt       time.Time   `json:"created_at"`
-	UpdatedAt       time.Time   `json:"updated_at"`
+	ID              string             `gorm:"type:uuid;primary_key" json:"id"`
+	UserID          string             `gorm:"type:uuid;not null;index" json:"user_id"`
+	Items           []OrderItem        `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"items"`
+	Status          string             `gorm:"type:varchar(50);not null;default:'pending'" json:"status"`
+	TotalPrice      float64            `gorm:"type:decimal(10,2);not null" json:"total_price"` // Subtotal - Discount + ShippingFee
+	Subtotal        float64            `gorm:"type:decimal(10,2);not null;default:0" json:"subtotal"`
+	Discount        float64            `gorm:"type:decimal(10,2);not null;default:0" json:"discount"`
+	ShippingFee     float64            `gorm:"type:decimal(10,2);not null;default:0" json:"shipping_fee"`
+	CouponCode      string             `gorm:"type:varchar(50)" json:"coupon_code"`
+	ShippingAddress string             `gorm:"type:text" json:"shipping_address"`
+	AddressID       string             `gorm:"type:varchar(36)" json:"address_id"` // Saved address the shipping details were copied from
+	RecipientName   string             `gorm:"type:varchar(200)" json:"recipient_name"`
+	RecipientPhone  string             `gorm:"type:varchar(20)" json:"recipient_phone"`
+	PaymentMethod   string             `gorm:"type:varchar(50)" json:"payment_method"`
+	History         []OrderStatusEvent `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"history"`
+	CreatedAt       time.Time          `json:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	AnonymizedAt    *time.Time         `json:"anonymized_at,omitempty"` // Set once the customer's personal data was erased
 }
 
 type OrderItem struct {
@@ -30,6 +68,17 @@ type OrderItem struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// OrderStatusEvent records a single status change of an order
+type OrderStatusEvent struct {
+	ID         string    `gorm:"type:uuid;primary_key" json:"id"`
+	OrderID    string    `gorm:"type:uuid;not null;index" json:"order_id"`
+	FromStatus string    `gorm:"type:varchar(50);not null" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(50);not null" json:"to_status"`
+	Actor      string    `gorm:"type:varchar(255)" json:"actor"`
+	Reason     string    `gorm:"type:text" json:"reason"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+}
+
 func (Order) TableName() string {
 	return "orders"
 }
@@ -38,6 +87,10 @@ func (OrderItem) TableName() string {
 	return "order_items"
 }
 
+func (OrderStatusEvent) TableName() string {
+	return "order_status_events"
+}
+
 func (o *Order) BeforeCreate(tx *gorm.DB) error {
 	if o.ID == "" {
 		o.ID = uuid.New().String()
@@ -52,6 +105,13 @@ func (oi *OrderItem) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+func (e *OrderStatusEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
+
 func (oi *OrderItem) GetSubtotal() float64 {
 	return oi.Price * float64(oi.Quantity)
 }
@@ -63,3 +123,19 @@ func (o *Order) CalculateTotalPrice() float64 {
 	}
 	return total
 }
+
+// IsValidStatus reports whether status is part of the order lifecycle
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether an order may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"errors"
+
+	"jumia-clone-backend/services/order-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCouponNotFound = errors.New("coupon not found")
+	// ErrCouponUsageLimitReached is returned when a coupon was redeemed as
+	// often as its usage limit allows, or was deactivated, by the time an
+	// order using it is saved
+	ErrCouponUsageLimitReached = errors.New("coupon is no longer available")
+	// ErrCouponUserLimitReached is returned when the user already redeemed
+	// the coupon as often as it allows
+	ErrCouponUserLimitReached = errors.New("coupon was already used the maximum number of times")
+)
+
+type CouponRepository interface {
+	Create(coupon *models.Coupon) error
+	Update(coupon *models.Coupon) error
+	Get(couponID string) (*models.Coupon, error)
+	GetByCode(code string) (*models.Coupon, error)
+	List(page, pageSize int) ([]models.Coupon, int64, error)
+	CountRedemptions(couponID, userID string) (int64, error)
+}
+
+type couponRepository struct {
+	db *gorm.DB
+}
+
+func NewCouponRepository(db *gorm.DB) CouponRepository {
+	return &couponRepository{db: db}
+}
+
+func (r *couponRepository) Create(coupon *models.Coupon) error {
+	return r.db.Create(coupon).Error
+}
+
+// Update saves a coupon's terms. The redemption count is left alone, as
+// orders may be changing it concurrently.
+func (r *couponRepository) Update(coupon *models.Coupon) error {
+	return r.db.Model(coupon).Select("*").Omit("id", "code", "times_redeemed", "created_at").Updates(coupon).Error
+}
+
+func (r *couponRepository) Get(couponID string) (*models.Coupon, error) {
+	var coupon models.Coupon
+	err := r.db.Where("id = ?", couponID).First(&coupon).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+func (r *couponRepository) GetByCode(code string) (*models.Coupon, error) {
+	var coupon models.Coupon
+	err := r.db.Where("code = ?", models.NormalizeCouponCode(code)).First(&coupon).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCouponNotFound
+		}
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// List returns coupons newest first
+func (r *couponRepository) List(page, pageSize int) ([]models.Coupon, int64, error) {
+	var coupons []models.Coupon
+	var total int64
+
+	if err := r.db.Model(&models.Coupon{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := r.db.Order("created_at DESC").Limit(pageSize).Offset(offset).Find(&coupons).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return coupons, total, nil
+}
+
+// CountRedemptions counts how often the user redeemed the coupon
+func (r *couponRepository) CountRedemptions(couponID, userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.CouponRedemption{}).
+		Where("coupon_id = ? AND user_id = ?", couponID, userID).
+		Count(&count).Error
+	return count, err
+}
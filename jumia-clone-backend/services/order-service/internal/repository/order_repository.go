@@ -2,6 +2,8 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"jumia-clone-backend/services/order-service/internal/models"
 
@@ -9,13 +11,25 @@ import (
 )
 
 type OrderRepository interface {
-	CreateOrder(order *models.Order) error
+	CreateOrder(order *models.Order, redemption *models.CouponRedemption) error
 	GetOrder(orderID string) (*models.Order, error)
 	ListOrders(userID string, page, pageSize int) ([]models.Order, int64, error)
-	UpdateOrderStatus(orderID, status string) error
-	CancelOrder(orderID, userID string) error
+	UpdateOrderStatus(orderID, fromStatus, toStatus, actor, reason string) error
+	CancelOrder(orderID, userID, reason string) error
+	DeleteOrder(orderID string) error
+	GetOrderHistory(orderID string) ([]models.OrderStatusEvent, error)
+	AnonymizeUserOrders(userID string) (int64, error)
+	ReleaseCouponRedemption(orderID string) error
 }
 
+var (
+	// ErrStatusChanged is returned when an order's status was changed by someone else mid-update
+	ErrStatusChanged = errors.New("order status changed, please retry")
+	// ErrOpenOrders is returned when a user's data cannot be erased because
+	// some of their orders still need the shipping details
+	ErrOpenOrders = errors.New("user has orders that are still being fulfilled")
+)
+
 type orderRepository struct {
 	db *gorm.DB
 }
@@ -24,13 +38,56 @@ func NewOrderRepository(db *gorm.DB) OrderRepository {
 	return &orderRepository{db: db}
 }
 
-func (r *orderRepository) CreateOrder(order *models.Order) error {
-	return r.db.Create(order).Error
+// CreateOrder saves an order. With a redemption, the coupon use is recorded
+// in the same transaction, and the order is refused if the coupon's usage
+// limits were reached in the meantime.
+func (r *orderRepository) CreateOrder(order *models.Order, redemption *models.CouponRedemption) error {
+	if redemption == nil {
+		return r.db.Create(order).Error
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Counting the use first locks the coupon row, so concurrent orders
+		// with the same coupon are checked one after the other
+		result := tx.Model(&models.Coupon{}).
+			Where("id = ? AND active = ? AND (usage_limit = 0 OR times_redeemed < usage_limit)", redemption.CouponID, true).
+			UpdateColumn("times_redeemed", gorm.Expr("times_redeemed + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCouponUsageLimitReached
+		}
+
+		var coupon models.Coupon
+		if err := tx.Where("id = ?", redemption.CouponID).First(&coupon).Error; err != nil {
+			return err
+		}
+		if coupon.PerUserLimit > 0 {
+			var used int64
+			err := tx.Model(&models.CouponRedemption{}).
+				Where("coupon_id = ? AND user_id = ?", coupon.ID, order.UserID).
+				Count(&used).Error
+			if err != nil {
+				return err
+			}
+			if used >= int64(coupon.PerUserLimit) {
+				return ErrCouponUserLimitReached
+			}
+		}
+
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+		redemption.OrderID = order.ID
+		redemption.UserID = order.UserID
+		return tx.Create(redemption).Error
+	})
 }
 
 func (r *orderRepository) GetOrder(orderID string) (*models.Order, error) {
 	var order models.Order
-	err := r.db.Where("id = ?", orderID).Preload("Items").First(&order).Error
+	err := r.db.Where("id = ?", orderID).Preload("Items").Preload("History", orderHistory).First(&order).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("order not found")
@@ -52,6 +109,7 @@ func (r *orderRepository) ListOrders(userID string, page, pageSize int) ([]model
 
 	offset := (page - 1) * pageSize
 	err := query.Preload("Items").
+		Preload("History", orderHistory).
 		Order("created_at DESC").
 		Limit(pageSize).
 		Offset(offset).
@@ -63,18 +121,32 @@ func (r *orderRepository) ListOrders(userID string, page, pageSize int) ([]model
 	return orders, total, nil
 }
 
-func (r *orderRepository) UpdateOrderStatus(orderID, status string) error {
-	result := r.db.Model(&models.Order{}).Where("id = ?", orderID).Update("status", status)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return errors.New("order not found")
-	}
-	return nil
+// UpdateOrderStatus moves an order to toStatus only if it is still in
+// fromStatus, so two concurrent updates cannot both apply. The change is
+// recorded in the order's status history in the same transaction.
+func (r *orderRepository) UpdateOrderStatus(orderID, fromStatus, toStatus, actor, reason string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", orderID, fromStatus).
+			Update("status", toStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStatusChanged
+		}
+
+		return tx.Create(&models.OrderStatusEvent{
+			OrderID:    orderID,
+			FromStatus: fromStatus,
+			ToStatus:   toStatus,
+			Actor:      actor,
+			Reason:     reason,
+		}).Error
+	})
 }
 
-func (r *orderRepository) CancelOrder(orderID, userID string) error {
+func (r *orderRepository) CancelOrder(orderID, userID, reason string) error {
 	var order models.Order
 	err := r.db.Where("id = ? AND user_id = ?", orderID, userID).First(&order).Error
 	if err != nil {
@@ -84,9 +156,111 @@ func (r *orderRepository) CancelOrder(orderID, userID string) error {
 		return err
 	}
 
-	if order.Status == "delivered" || order.Status == "cancelled" {
+	if !models.CanTransition(order.Status, models.StatusCancelled) {
 		return errors.New("cannot cancel order with status: " + order.Status)
 	}
 
-	return r.db.Model(&order).Update("status", "cancelled").Error
+	// Only one concurrent cancellation may succeed, otherwise stock would be released twice
+	return r.UpdateOrderStatus(order.ID, order.Status, models.StatusCancelled, userID, reason)
+}
+
+// DeleteOrder deletes an order with its items and gives back its coupon use
+func (r *orderRepository) DeleteOrder(orderID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := releaseCouponRedemption(tx, orderID); err != nil {
+			return err
+		}
+		return tx.Select("Items").Delete(&models.Order{ID: orderID}).Error
+	})
+}
+
+// ReleaseCouponRedemption gives back the coupon use of a cancelled order, so
+// it counts against neither the user's nor the global limit
+func (r *orderRepository) ReleaseCouponRedemption(orderID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return releaseCouponRedemption(tx, orderID)
+	})
+}
+
+func (r *orderRepository) GetOrderHistory(orderID string) ([]models.OrderStatusEvent, error) {
+	var count int64
+	if err := r.db.Model(&models.Order{}).Where("id = ?", orderID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.New("order not found")
+	}
+
+	var events []models.OrderStatusEvent
+	err := orderHistory(r.db.Where("order_id = ?", orderID)).Find(&events).Error
+	return events, err
+}
+
+// AnonymizeUserOrders blanks the shipping details of all the user's orders
+// and the free-text reasons in their status history. Items, prices and
+// totals are kept for accounting. Returns the number of orders changed, or
+// ErrOpenOrders if any order is still open. Open orders are never touched,
+// including ones placed while this runs.
+func (r *orderRepository) AnonymizeUserOrders(userID string) (int64, error) {
+	var anonymized int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var open int64
+		if err := tx.Model(&models.Order{}).
+			Where("user_id = ? AND status IN ?", userID, models.OpenStatuses).
+			Count(&open).Error; err != nil {
+			return err
+		}
+		if open > 0 {
+			return fmt.Errorf("%w: %d open", ErrOpenOrders, open)
+		}
+
+		orderIDs := tx.Model(&models.Order{}).Select("id").
+			Where("user_id = ? AND status NOT IN ?", userID, models.OpenStatuses)
+		if err := tx.Model(&models.OrderStatusEvent{}).
+			Where("order_id IN (?)", orderIDs).
+			Update("reason", "").Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Order{}).
+			Where("user_id = ? AND status NOT IN ? AND anonymized_at IS NULL", userID, models.OpenStatuses).
+			Updates(map[string]interface{}{
+				"shipping_address": "",
+				"address_id":       "",
+				"recipient_name":   "",
+				"recipient_phone":  "",
+				"anonymized_at":    time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		anonymized = result.RowsAffected
+		return nil
+	})
+	return anonymized, err
+}
+
+// orderHistory orders status events oldest first
+func orderHistory(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC")
+}
+
+// releaseCouponRedemption deletes the order's coupon redemption, if any, and
+// takes it off the coupon's redemption count
+func releaseCouponRedemption(tx *gorm.DB, orderID string) error {
+	var redemption models.CouponRedemption
+	err := tx.Where("order_id = ?", orderID).First(&redemption).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Delete(&redemption).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Coupon{}).
+		Where("id = ? AND times_redeemed > 0", redemption.CouponID).
+		UpdateColumn("times_redeemed", gorm.Expr("times_redeemed - 1")).Error
 }
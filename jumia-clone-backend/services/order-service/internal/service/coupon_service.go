@@ -0,0 +1,297 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"jumia-clone-backend/services/order-service/internal/client"
+	"jumia-clone-backend/services/order-service/internal/models"
+	"jumia-clone-backend/services/order-service/internal/repository"
+)
+
+// Codes of a CouponError
+const (
+	CouponNotFound      = "not_found"
+	CouponInactive      = "inactive"
+	CouponNotStarted    = "not_started"
+	CouponExpired       = "expired"
+	CouponUsageLimit    = "usage_limit_reached"
+	CouponUserLimit     = "user_limit_reached"
+	CouponMinBasket     = "min_basket_not_met"
+	CouponNotApplicable = "not_applicable"
+)
+
+var (
+	// ErrInvalidCoupon is returned for coupon terms that cannot be saved
+	ErrInvalidCoupon = errors.New("invalid coupon")
+	// ErrCouponCodeTaken is returned when another coupon has the code
+	ErrCouponCodeTaken = errors.New("a coupon with this code already exists")
+
+	couponCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,50}$`)
+)
+
+// CouponError is returned when a coupon cannot be used on a basket.
+// MinBasket is set when the basket is too small.
+type CouponError struct {
+	Code      string
+	MinBasket float64
+}
+
+func (e *CouponError) Error() string {
+	switch e.Code {
+	case CouponNotFound:
+		return "coupon code is not valid"
+	case CouponNotStarted:
+		return "coupon is not valid yet"
+	case CouponExpired:
+		return "coupon has expired"
+	case CouponUsageLimit, CouponInactive:
+		return "coupon is no longer available"
+	case CouponUserLimit:
+		return "coupon was already used the maximum number of times"
+	case CouponMinBasket:
+		return fmt.Sprintf("coupon requires a basket of at least %.2f", e.MinBasket)
+	default:
+		return "coupon does not apply to any item in the basket"
+	}
+}
+
+// CouponInput holds the terms of a coupon being created or updated
+type CouponInput struct {
+	Code         string
+	Description  string
+	Type         string
+	Value        float64
+	MinBasket    float64
+	UsageLimit   int
+	PerUserLimit int
+	StartsAt     *time.Time
+	ExpiresAt    *time.Time
+	Categories   []string
+	Brands       []string
+	Active       bool
+}
+
+// CouponLine is an order line as far as coupons are concerned
+type CouponLine struct {
+	Category string
+	Brand    string
+	Subtotal float64
+}
+
+// CouponQuote is what a coupon takes off a basket. Discount is off the
+// items; a free shipping coupon waives the shipping fee instead.
+type CouponQuote struct {
+	CouponID         string
+	Code             string
+	Type             string
+	Discount         float64
+	FreeShipping     bool
+	EligibleSubtotal float64
+}
+
+type CouponService interface {
+	CreateCoupon(input CouponInput) (*models.Coupon, error)
+	UpdateCoupon(couponID string, input CouponInput) (*models.Coupon, error)
+	GetCoupon(couponID string) (*models.Coupon, error)
+	ListCoupons(page, pageSize int) ([]models.Coupon, int64, error)
+	ValidateCoupon(code, userID string, items []OrderItemInput) (*CouponQuote, error)
+	Quote(code, userID string, lines []CouponLine) (*CouponQuote, error)
+}
+
+type couponService struct {
+	repo     repository.CouponRepository
+	products client.ProductClient
+	now      func() time.Time
+}
+
+func NewCouponService(repo repository.CouponRepository, products client.ProductClient) CouponService {
+	return &couponService{repo: repo, products: products, now: time.Now}
+}
+
+func (s *couponService) CreateCoupon(input CouponInput) (*models.Coupon, error) {
+	coupon := &models.Coupon{}
+	if err := applyCouponInput(coupon, input); err != nil {
+		return nil, err
+	}
+
+	_, err := s.repo.GetByCode(coupon.Code)
+	if err == nil {
+		return nil, ErrCouponCodeTaken
+	}
+	if !errors.Is(err, repository.ErrCouponNotFound) {
+		return nil, err
+	}
+
+	if err := s.repo.Create(coupon); err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
+
+// UpdateCoupon replaces the terms of a coupon. The code cannot change, since
+// customers may already have it in their carts.
+func (s *couponService) UpdateCoupon(couponID string, input CouponInput) (*models.Coupon, error) {
+	coupon, err := s.repo.Get(couponID)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Code = coupon.Code
+	if err := applyCouponInput(coupon, input); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(coupon); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Get(couponID)
+}
+
+func (s *couponService) GetCoupon(couponID string) (*models.Coupon, error) {
+	return s.repo.Get(couponID)
+}
+
+func (s *couponService) ListCoupons(page, pageSize int) ([]models.Coupon, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return s.repo.List(page, pageSize)
+}
+
+// ValidateCoupon works out what a coupon is worth on a basket, pricing the
+// items with product service as an order would
+func (s *couponService) ValidateCoupon(code, userID string, items []OrderItemInput) (*CouponQuote, error) {
+	lines := make([]CouponLine, 0, len(items))
+	for _, item := range items {
+		product, err := s.products.GetProduct(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("product %s is not available: %w", item.ProductID, err)
+		}
+		lines = append(lines, CouponLine{
+			Category: product.Category,
+			Brand:    product.Brand,
+			Subtotal: unitPrice(product) * float64(item.Quantity),
+		})
+	}
+
+	return s.Quote(code, userID, lines)
+}
+
+// Quote checks that the user may use the coupon on a basket of the given
+// lines and works out the discount. It does not redeem the coupon.
+func (s *couponService) Quote(code, userID string, lines []CouponLine) (*CouponQuote, error) {
+	if models.NormalizeCouponCode(code) == "" {
+		return nil, &CouponError{Code: CouponNotFound}
+	}
+	coupon, err := s.repo.GetByCode(code)
+	if errors.Is(err, repository.ErrCouponNotFound) {
+		return nil, &CouponError{Code: CouponNotFound}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	switch {
+	case !coupon.Active:
+		return nil, &CouponError{Code: CouponInactive}
+	case coupon.StartsAt != nil && now.Before(*coupon.StartsAt):
+		return nil, &CouponError{Code: CouponNotStarted}
+	case coupon.ExpiresAt != nil && !now.Before(*coupon.ExpiresAt):
+		return nil, &CouponError{Code: CouponExpired}
+	case coupon.UsageLimit > 0 && coupon.TimesRedeemed >= coupon.UsageLimit:
+		return nil, &CouponError{Code: CouponUsageLimit}
+	}
+
+	if coupon.PerUserLimit > 0 {
+		used, err := s.repo.CountRedemptions(coupon.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= int64(coupon.PerUserLimit) {
+			return nil, &CouponError{Code: CouponUserLimit}
+		}
+	}
+
+	var subtotal, eligible float64
+	for _, line := range lines {
+		subtotal += line.Subtotal
+		if coupon.Applies(line.Category, line.Brand) {
+			eligible += line.Subtotal
+		}
+	}
+	if coupon.MinBasket > 0 && models.RoundPrice(subtotal) < coupon.MinBasket {
+		return nil, &CouponError{Code: CouponMinBasket, MinBasket: coupon.MinBasket}
+	}
+	if eligible <= 0 {
+		return nil, &CouponError{Code: CouponNotApplicable}
+	}
+
+	quote := &CouponQuote{
+		CouponID:         coupon.ID,
+		Code:             coupon.Code,
+		Type:             coupon.Type,
+		EligibleSubtotal: models.RoundPrice(eligible),
+	}
+	switch coupon.Type {
+	case models.CouponPercentage:
+		quote.Discount = models.RoundPrice(eligible * coupon.Value / 100)
+	case models.CouponFixed:
+		quote.Discount = models.RoundPrice(math.Min(coupon.Value, eligible))
+	case models.CouponFreeShipping:
+		quote.FreeShipping = true
+	}
+	return quote, nil
+}
+
+// applyCouponInput checks coupon terms and copies them onto coupon
+func applyCouponInput(coupon *models.Coupon, input CouponInput) error {
+	code := models.NormalizeCouponCode(input.Code)
+	if !couponCodePattern.MatchString(code) {
+		return fmt.Errorf("%w: code must be 3 to 50 letters, digits, dashes or underscores", ErrInvalidCoupon)
+	}
+	if !models.IsValidCouponType(input.Type) {
+		return fmt.Errorf("%w: type must be percentage, fixed or free_shipping", ErrInvalidCoupon)
+	}
+
+	switch input.Type {
+	case models.CouponPercentage:
+		if input.Value <= 0 || input.Value > 100 {
+			return fmt.Errorf("%w: a percentage must be above 0 and at most 100", ErrInvalidCoupon)
+		}
+	case models.CouponFixed:
+		if input.Value <= 0 {
+			return fmt.Errorf("%w: a fixed amount must be above 0", ErrInvalidCoupon)
+		}
+	case models.CouponFreeShipping:
+		input.Value = 0
+	}
+
+	if input.MinBasket < 0 || input.UsageLimit < 0 || input.PerUserLimit < 0 {
+		return fmt.Errorf("%w: min_basket and limits cannot be negative", ErrInvalidCoupon)
+	}
+	if input.StartsAt != nil && input.ExpiresAt != nil && !input.ExpiresAt.After(*input.StartsAt) {
+		return fmt.Errorf("%w: expires_at must be after starts_at", ErrInvalidCoupon)
+	}
+
+	coupon.Code = code
+	coupon.Description = input.Description
+	coupon.Type = input.Type
+	coupon.Value = models.RoundPrice(input.Value)
+	coupon.MinBasket = models.RoundPrice(input.MinBasket)
+	coupon.UsageLimit = input.UsageLimit
+	coupon.PerUserLimit = input.PerUserLimit
+	coupon.StartsAt = input.StartsAt
+	coupon.ExpiresAt = input.ExpiresAt
+	coupon.Categories = models.JoinList(input.Categories)
+	coupon.Brands = models.JoinList(input.Brands)
+	coupon.Active = input.Active
+	return nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"jumia-clone-backend/services/order-service/internal/models"
+	"jumia-clone-backend/services/order-service/internal/repository"
+)
+
+// quoteRepository holds coupons by code and each user's redemption count
+type quoteRepository struct {
+	repository.CouponRepository
+	coupons     map[string]*models.Coupon
+	redemptions map[string]int64
+}
+
+func (r *quoteRepository) GetByCode(code string) (*models.Coupon, error) {
+	coupon, ok := r.coupons[models.NormalizeCouponCode(code)]
+	if !ok {
+		return nil, repository.ErrCouponNotFound
+	}
+	return coupon, nil
+}
+
+func (r *quoteRepository) CountRedemptions(couponID, userID string) (int64, error) {
+	return r.redemptions[userID], nil
+}
+
+func TestQuote(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		when := now.Add(d)
+		return &when
+	}
+
+	phone := CouponLine{Category: "Electronics", Brand: "Apple", Subtotal: 1000}
+	shirt := CouponLine{Category: "Fashion", Brand: "Nike", Subtotal: 200}
+
+	tests := []struct {
+		name         string
+		coupon       models.Coupon
+		inactive     bool
+		code         string
+		userID       string
+		lines        []CouponLine
+		wantErr      string // CouponError code
+		wantDiscount float64
+		wantEligible float64
+		wantShipping bool
+	}{
+		{
+			name:         "percentage of the whole basket",
+			coupon:       models.Coupon{Type: models.CouponPercentage, Value: 10},
+			lines:        []CouponLine{phone, shirt},
+			wantDiscount: 120,
+			wantEligible: 1200,
+		},
+		{
+			name:         "percentage rounds to cents",
+			coupon:       models.Coupon{Type: models.CouponPercentage, Value: 15},
+			lines:        []CouponLine{{Subtotal: 33.33}},
+			wantDiscount: 5,
+			wantEligible: 33.33,
+		},
+		{
+			name:         "fixed amount below the eligible total",
+			coupon:       models.Coupon{Type: models.CouponFixed, Value: 50},
+			lines:        []CouponLine{phone, shirt},
+			wantDiscount: 50,
+			wantEligible: 1200,
+		},
+		{
+			name:         "fixed amount capped at the eligible total",
+			coupon:       models.Coupon{Type: models.CouponFixed, Value: 500, Categories: "Fashion"},
+			lines:        []CouponLine{phone, shirt},
+			wantDiscount: 200,
+			wantEligible: 200,
+		},
+		{
+			name:         "free shipping takes nothing off the items",
+			coupon:       models.Coupon{Type: models.CouponFreeShipping},
+			lines:        []CouponLine{shirt},
+			wantEligible: 200,
+			wantShipping: true,
+		},
+		{
+			name:         "category restriction counts matching lines only",
+			coupon:       models.Coupon{Type: models.CouponPercentage, Value: 10, Categories: "electronics"},
+			lines:        []CouponLine{phone, shirt},
+			wantDiscount: 100,
+			wantEligible: 1000,
+		},
+		{
+			name:         "brand restriction counts matching lines only",
+			coupon:       models.Coupon{Type: models.CouponPercentage, Value: 50, Brands: "Nike,Adidas"},
+			lines:        []CouponLine{phone, shirt},
+			wantDiscount: 100,
+			wantEligible: 200,
+		},
+		{
+			name:    "category and brand must both match",
+			coupon:  models.Coupon{Type: models.CouponPercentage, Value: 10, Categories: "Electronics", Brands: "Nike"},
+			lines:   []CouponLine{phone, shirt},
+			wantErr: CouponNotApplicable,
+		},
+		{
+			name:         "min basket met exactly",
+			coupon:       models.Coupon{Type: models.CouponFixed, Value: 20, MinBasket: 1200},
+			lines:        []CouponLine{phone, shirt},
+			wantDiscount: 20,
+			wantEligible: 1200,
+		},
+		{
+			name:    "min basket counts the whole basket, not just eligible lines",
+			coupon:  models.Coupon{Type: models.CouponFixed, Value: 20, MinBasket: 1200.01, Categories: "Fashion"},
+			lines:   []CouponLine{phone, shirt},
+			wantErr: CouponMinBasket,
+		},
+		{
+			name:    "unknown code",
+			code:    "NOPE",
+			coupon:  models.Coupon{Type: models.CouponFixed, Value: 20},
+			lines:   []CouponLine{phone},
+			wantErr: CouponNotFound,
+		},
+		{
+			name:    "blank code",
+			code:    "  ",
+			coupon:  models.Coupon{Type: models.CouponFixed, Value: 20},
+			lines:   []CouponLine{phone},
+			wantErr: CouponNotFound,
+		},
+		{
+			name:         "code matches regardless of case",
+			code:         " save10 ",
+			coupon:       models.Coupon{Type: models.CouponFixed, Value: 20},
+			lines:        []CouponLine{phone},
+			wantDiscount: 20,
+			wantEligible: 1000,
+		},
+		{
+			name:     "inactive",
+			coupon:   models.Coupon{Type: models.CouponFixed, Value: 20},
+			inactive: true,
+			lines:    []CouponLine{phone},
+			wantErr:  CouponInactive,
+		},
+		{
+			name:         "valid from the moment it starts",
+			coupon:       models.Coupon{Type: models.CouponFixed, Value: 20, StartsAt: at(0)},
+			lines:        []CouponLine{phone},
+			wantDiscount: 20,
+			wantEligible: 1000,
+		},
+		{
+			name:    "not started until its start time",
+			coupon:  models.Coupon{Type: models.CouponFixed, Value: 20, StartsAt: at(time.Second)},
+			lines:   []CouponLine{phone},
+			wantErr: CouponNotStarted,
+		},
+		{
+			name:         "valid until just before it expires",
+			coupon:       models.Coupon{Type: models.CouponFixed, Value: 20, ExpiresAt: at(time.Nanosecond)},
+			lines:        []CouponLine{phone},
+			wantDiscount: 20,
+			wantEligible: 1000,
+		},
+		{
+			name:    "expired at its expiry time",
+			coupon:  models.Coupon{Type: models.CouponFixed, Value: 20, ExpiresAt: at(0)},
+			lines:   []CouponLine{phone},
+			wantErr: CouponExpired,
+		},
+		{
+			name:    "usage limit reached",
+			coupon:  models.Coupon{Type: models.CouponFixed, Value: 20, UsageLimit: 5, TimesRedeemed: 5},
+			lines:   []CouponLine{phone},
+			wantErr: CouponUsageLimit,
+		},
+		{
+			name:    "per-user limit reached",
+			coupon:  models.Coupon{Type: models.CouponFixed, Value: 20, PerUserLimit: 1},
+			userID:  "returning-user",
+			lines:   []CouponLine{phone},
+			wantErr: CouponUserLimit,
+		},
+		{
+			name:         "per-user limit not reached by another user",
+			coupon:       models.Coupon{Type: models.CouponFixed, Value: 20, PerUserLimit: 1},
+			userID:       "new-user",
+			lines:        []CouponLine{phone},
+			wantDiscount: 20,
+			wantEligible: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon := tt.coupon
+			coupon.ID = "coupon-1"
+			coupon.Code = "SAVE10"
+			coupon.Active = !tt.inactive
+			repo := &quoteRepository{
+				coupons:     map[string]*models.Coupon{coupon.Code: &coupon},
+				redemptions: map[string]int64{"returning-user": 1},
+			}
+			s := &couponService{repo: repo, now: func() time.Time { return now }}
+
+			code := tt.code
+			if code == "" {
+				code = "SAVE10"
+			}
+			quote, err := s.Quote(code, tt.userID, tt.lines)
+
+			if tt.wantErr != "" {
+				var couponErr *CouponError
+				if !errors.As(err, &couponErr) || couponErr.Code != tt.wantErr {
+					t.Fatalf("err = %v, want CouponError %s", err, tt.wantErr)
+				}
+				if tt.wantErr == CouponMinBasket && couponErr.MinBasket != coupon.MinBasket {
+					t.Errorf("MinBasket = %v, want %v", couponErr.MinBasket, coupon.MinBasket)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Quote: %v", err)
+			}
+			if quote.Discount != tt.wantDiscount {
+				t.Errorf("Discount = %v, want %v", quote.Discount, tt.wantDiscount)
+			}
+			if quote.EligibleSubtotal != tt.wantEligible {
+				t.Errorf("EligibleSubtotal = %v, want %v", quote.EligibleSubtotal, tt.wantEligible)
+			}
+			if quote.FreeShipping != tt.wantShipping {
+				t.Errorf("FreeShipping = %v, want %v", quote.FreeShipping, tt.wantShipping)
+			}
+			if quote.CouponID != coupon.ID || quote.Code != coupon.Code {
+				t.Errorf("quote is for %s/%s, want %s/%s", quote.CouponID, quote.Code, coupon.ID, coupon.Code)
+			}
+		})
+	}
+}
@@ -1,58 +1,163 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"jumia-clone-backend/services/order-service/internal/client"
 	"jumia-clone-backend/services/order-service/internal/models"
 	"jumia-clone-backend/services/order-service/internal/repository"
+	pb "jumia-clone-backend/services/order-service/proto"
 )
 
 type OrderService interface {
-	CreateOrder(userID, shippingAddress, paymentMethod string, items []OrderItemInput) (*models.Order, error)
+	CreateOrder(userID, addressID, shippingAddress, paymentMethod, couponCode string, items []OrderItemInput) (*models.Order, error)
 	GetOrder(orderID string) (*models.Order, error)
 	ListOrders(userID string, page, pageSize int) ([]models.Order, int64, error)
-	UpdateOrderStatus(orderID, status string) (*models.Order, error)
-	CancelOrder(orderID, userID string) error
+	UpdateOrderStatus(orderID, status, actor, reason string) (*models.Order, error)
+	CancelOrder(orderID, userID, reason string) error
+	Checkout(userID, addressID, shippingAddress, paymentMethod string) (*models.Order, error)
+	GetOrderHistory(orderID string) ([]models.OrderStatusEvent, error)
+	AnonymizeUserOrders(userID string) (int64, error)
 }
 
+// OrderItemInput is a requested order line. Name and price are not taken
+// from the client; they are looked up in product service.
 type OrderItemInput struct {
-	ProductID   string
-	ProductName string
-	Quantity    int
-	Price       float64
+	ProductID string
+	Quantity  int
 }
 
+var (
+	// ErrInvalidStatus is returned for a status outside the order lifecycle
+	ErrInvalidStatus = errors.New("invalid order status")
+	// ErrInvalidTransition is returned when the lifecycle does not allow the requested change
+	ErrInvalidTransition = errors.New("invalid order status transition")
+	// ErrOpenOrders is returned when a user's data cannot be erased because
+	// some of their orders still need the shipping details
+	ErrOpenOrders = repository.ErrOpenOrders
+)
+
 type orderService struct {
-	repo repository.OrderRepository
+	repo          repository.OrderRepository
+	coupons       CouponService
+	products      client.ProductClient
+	carts         client.CartClient
+	users         client.UserClient
+	shippingFee   float64
+	maxPerProduct int
 }
 
-func NewOrderService(repo repository.OrderRepository) OrderService {
-	return &orderService{repo: repo}
+// NewOrderService creates the order service. shippingFee is charged on
+// every order unless a free shipping coupon is used. maxPerProduct limits
+// the units of a product in an order unless the product sets its own
+// max_per_order, as cart service does for carts.
+func NewOrderService(repo repository.OrderRepository, coupons CouponService, products client.ProductClient, carts client.CartClient, users client.UserClient, shippingFee float64, maxPerProduct int) OrderService {
+	return &orderService{repo: repo, coupons: coupons, products: products, carts: carts, users: users, shippingFee: shippingFee, maxPerProduct: maxPerProduct}
 }
 
-func (s *orderService) CreateOrder(userID, shippingAddress, paymentMethod string, items []OrderItemInput) (*models.Order, error) {
+// CreateOrder places an order. With an addressID the shipping details are
+// copied from the user's saved address, so later edits to the address do
+// not change the order; otherwise shippingAddress is used as given. A coupon
+// is redeemed in the same transaction that saves the order.
+func (s *orderService) CreateOrder(userID, addressID, shippingAddress, paymentMethod, couponCode string, items []OrderItemInput) (*models.Order, error) {
+	if len(items) == 0 {
+		return nil, errors.New("order must contain at least one item")
+	}
+
+	order := &models.Order{
+		UserID:          userID,
+		Status:          models.StatusPending,
+		ShippingAddress: shippingAddress,
+		PaymentMethod:   paymentMethod,
+	}
+	if addressID != "" {
+		address, err := s.users.GetAddress(addressID, userID)
+		if err != nil {
+			return nil, fmt.Errorf("shipping address is not available: %w", err)
+		}
+		order.AddressID = address.Id
+		order.RecipientName = address.RecipientName
+		order.RecipientPhone = address.Phone
+		order.ShippingAddress = fmt.Sprintf("%s, %s, %s", address.Street, address.City, address.Region)
+	}
+
 	orderItems := make([]models.OrderItem, 0, len(items))
-	var totalPrice float64
+	couponLines := make([]CouponLine, 0, len(items))
+	var subtotal float64
 
 	for _, item := range items {
+		if item.Quantity < 1 {
+			return nil, fmt.Errorf("invalid quantity for product %s", item.ProductID)
+		}
+
+		product, err := s.products.GetProduct(item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("product %s is not available: %w", item.ProductID, err)
+		}
+		if product.Stock < int32(item.Quantity) {
+			return nil, fmt.Errorf("insufficient stock for %s", product.Name)
+		}
+		if limit := s.perOrderLimit(product); limit > 0 && item.Quantity > limit {
+			return nil, fmt.Errorf("at most %d of %s can be ordered at once", limit, product.Name)
+		}
+
+		price := unitPrice(product)
 		orderItem := models.OrderItem{
-			ProductID:   item.ProductID,
-			ProductName: item.ProductName,
+			ProductID:   product.Id,
+			ProductName: product.Name,
 			Quantity:    item.Quantity,
-			Price:       item.Price,
+			Price:       price,
 		}
 		orderItems = append(orderItems, orderItem)
-		totalPrice += item.Price * float64(item.Quantity)
+		couponLines = append(couponLines, CouponLine{
+			Category: product.Category,
+			Brand:    product.Brand,
+			Subtotal: orderItem.GetSubtotal(),
+		})
+		subtotal += orderItem.GetSubtotal()
 	}
 
-	order := &models.Order{
-		UserID:          userID,
-		Items:           orderItems,
-		Status:          "pending",
-		TotalPrice:      totalPrice,
-		ShippingAddress: shippingAddress,
-		PaymentMethod:   paymentMethod,
+	order.Items = orderItems
+	order.Subtotal = models.RoundPrice(subtotal)
+	order.ShippingFee = s.shippingFee
+
+	var redemption *models.CouponRedemption
+	if couponCode != "" {
+		quote, err := s.coupons.Quote(couponCode, userID, couponLines)
+		if err != nil {
+			return nil, err
+		}
+
+		waived := 0.0
+		if quote.FreeShipping {
+			waived = order.ShippingFee
+			order.ShippingFee = 0
+		}
+		order.Discount = quote.Discount
+		order.CouponCode = quote.Code
+		redemption = &models.CouponRedemption{
+			CouponID: quote.CouponID,
+			Discount: models.RoundPrice(quote.Discount + waived),
+		}
+	}
+	order.TotalPrice = models.RoundPrice(order.Subtotal - order.Discount + order.ShippingFee)
+
+	quantities := stockQuantities(orderItems)
+	if err := s.products.ReserveStock(quantities); err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.CreateOrder(order); err != nil {
+	if err := s.repo.CreateOrder(order, redemption); err != nil {
+		s.releaseStock(quantities)
+		switch {
+		case errors.Is(err, repository.ErrCouponUsageLimitReached):
+			return nil, &CouponError{Code: CouponUsageLimit}
+		case errors.Is(err, repository.ErrCouponUserLimitReached):
+			return nil, &CouponError{Code: CouponUserLimit}
+		}
 		return nil, err
 	}
 
@@ -73,13 +178,147 @@ func (s *orderService) ListOrders(userID string, page, pageSize int) ([]models.O
 	return s.repo.ListOrders(userID, page, pageSize)
 }
 
-func (s *orderService) UpdateOrderStatus(orderID, status string) (*models.Order, error) {
-	if err := s.repo.UpdateOrderStatus(orderID, status); err != nil {
+func (s *orderService) UpdateOrderStatus(orderID, status, actor, reason string) (*models.Order, error) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if !models.IsValidStatus(status) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatus, status)
+	}
+
+	order, err := s.repo.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !models.CanTransition(order.Status, status) {
+		return nil, fmt.Errorf("%w from %s to %s", ErrInvalidTransition, order.Status, status)
+	}
+
+	if err := s.repo.UpdateOrderStatus(orderID, order.Status, status, actor, reason); err != nil {
+		if errors.Is(err, repository.ErrStatusChanged) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTransition, err)
+		}
 		return nil, err
 	}
+	if status == models.StatusCancelled {
+		s.releaseStock(stockQuantities(order.Items))
+		s.releaseCoupon(orderID)
+	}
+
 	return s.repo.GetOrder(orderID)
 }
 
-func (s *orderService) CancelOrder(orderID, userID string) error {
-	return s.repo.CancelOrder(orderID, userID)
+func (s *orderService) CancelOrder(orderID, userID, reason string) error {
+	if err := s.repo.CancelOrder(orderID, userID, reason); err != nil {
+		return err
+	}
+
+	order, err := s.repo.GetOrder(orderID)
+	if err != nil {
+		return err
+	}
+	s.releaseStock(stockQuantities(order.Items))
+	s.releaseCoupon(orderID)
+	return nil
+}
+
+func (s *orderService) GetOrderHistory(orderID string) ([]models.OrderStatusEvent, error) {
+	return s.repo.GetOrderHistory(orderID)
+}
+
+// AnonymizeUserOrders erases the personal data on a user's orders while
+// keeping the financial records. It refuses while any order is still open,
+// since those still have to be delivered.
+func (s *orderService) AnonymizeUserOrders(userID string) (int64, error) {
+	if userID == "" {
+		return 0, errors.New("user ID is required")
+	}
+
+	return s.repo.AnonymizeUserOrders(userID)
+}
+
+// Checkout places an order for everything in the user's cart, with the
+// cart's coupon. Prices come from product service, not from the cart. The
+// cart is cleared only after the order is saved; if clearing fails the order
+// is rolled back so the customer can simply retry.
+func (s *orderService) Checkout(userID, addressID, shippingAddress, paymentMethod string) (*models.Order, error) {
+	cart, err := s.carts.GetCart(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cart: %w", err)
+	}
+	if len(cart.Items) == 0 {
+		return nil, errors.New("cart is empty")
+	}
+
+	items := make([]OrderItemInput, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, OrderItemInput{
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
+		})
+	}
+
+	order, err := s.CreateOrder(userID, addressID, shippingAddress, paymentMethod, cart.GetCoupon().GetCode(), items)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.carts.ClearCart(userID); err != nil {
+		s.rollbackOrder(order)
+		return nil, fmt.Errorf("failed to clear cart: %w", err)
+	}
+
+	return order, nil
+}
+
+// rollbackOrder undoes a freshly created order and its stock reservation
+func (s *orderService) rollbackOrder(order *models.Order) {
+	if err := s.repo.DeleteOrder(order.ID); err != nil {
+		log.Printf("Failed to roll back order %s: %v", order.ID, err)
+		return
+	}
+	s.releaseStock(stockQuantities(order.Items))
+}
+
+// releaseStock returns reserved units to product service. A failure here
+// must not undo the order change that triggered it, so it is only logged.
+func (s *orderService) releaseStock(quantities map[string]int) {
+	if err := s.products.ReleaseStock(quantities); err != nil {
+		log.Printf("Failed to release stock %v: %v", quantities, err)
+	}
+}
+
+// releaseCoupon gives back the coupon use of a cancelled order. Like
+// releasing stock, a failure is only logged.
+func (s *orderService) releaseCoupon(orderID string) {
+	if err := s.repo.ReleaseCouponRedemption(orderID); err != nil {
+		log.Printf("Failed to release coupon of order %s: %v", orderID, err)
+	}
+}
+
+// stockQuantities sums ordered units per product
+func stockQuantities(items []models.OrderItem) map[string]int {
+	quantities := make(map[string]int, len(items))
+	for _, item := range items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	return quantities
+}
+
+// perOrderLimit returns the product's max_per_order, or the default limit
+// when the product sets none. Zero means no limit. Cart service applies the
+// same rule, so an order can hold what a cart can.
+func (s *orderService) perOrderLimit(product *pb.ProductData) int {
+	if product.MaxPerOrder > 0 {
+		return int(product.MaxPerOrder)
+	}
+	return s.maxPerProduct
+}
+
+// unitPrice returns the price a customer pays for one unit of the product:
+// the flash sale price while a flash sale is running, otherwise the
+// discounted final price.
+func unitPrice(product *pb.ProductData) float64 {
+	if product.IsFlashSaleActive && product.FlashSalePrice > 0 {
+		return product.FlashSalePrice
+	}
+	return product.FinalPrice
 }
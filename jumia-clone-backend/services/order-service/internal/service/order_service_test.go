@@ -0,0 +1,83 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"jumia-clone-backend/services/order-service/internal/client"
+	"jumia-clone-backend/services/order-service/internal/models"
+	"jumia-clone-backend/services/order-service/internal/repository"
+	pb "jumia-clone-backend/services/order-service/proto"
+)
+
+// stockedProducts serves one product and accepts every stock reservation
+type stockedProducts struct {
+	client.ProductClient
+	product *pb.ProductData
+}
+
+func (p *stockedProducts) GetProduct(productID string) (*pb.ProductData, error) {
+	return p.product, nil
+}
+
+func (p *stockedProducts) ReserveStock(quantities map[string]int) error {
+	return nil
+}
+
+// savedOrders keeps the last order created
+type savedOrders struct {
+	repository.OrderRepository
+	order *models.Order
+}
+
+func (r *savedOrders) CreateOrder(order *models.Order, redemption *models.CouponRedemption) error {
+	order.ID = "order-1"
+	r.order = order
+	return nil
+}
+
+func (r *savedOrders) GetOrder(orderID string) (*models.Order, error) {
+	return r.order, nil
+}
+
+func TestCreateOrderPerOrderLimit(t *testing.T) {
+	const defaultLimit = 10
+
+	tests := []struct {
+		name        string
+		maxPerOrder int32
+		quantity    int
+		ok          bool
+	}{
+		{"default limit when the product sets none", 0, defaultLimit, true},
+		{"above the default limit when the product sets none", 0, defaultLimit + 1, false},
+		{"product limit above the default", 20, 20, true},
+		{"above the product limit", 20, 21, false},
+		{"product limit below the default", 2, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := &stockedProducts{product: &pb.ProductData{
+				Id:          "product-1",
+				Name:        "Phone",
+				Price:       100,
+				FinalPrice:  100,
+				Stock:       100,
+				InStock:     true,
+				MaxPerOrder: tt.maxPerOrder,
+			}}
+			s := &orderService{repo: &savedOrders{}, products: products, maxPerProduct: defaultLimit}
+
+			_, err := s.CreateOrder("user-1", "", "1 Main St", "card", "", []OrderItemInput{
+				{ProductID: "product-1", Quantity: tt.quantity},
+			})
+			if tt.ok && err != nil {
+				t.Fatalf("CreateOrder: %v", err)
+			}
+			if !tt.ok && (err == nil || !strings.Contains(err.Error(), "can be ordered at once")) {
+				t.Fatalf("err = %v, want the per-order limit error", err)
+			}
+		})
+	}
+}
@@ -45,6 +45,7 @@ func (h *ProductServiceHandler) CreateProduct(ctx context.Context, req *pb.Creat
 		req.IsTopDeal,
 		req.DealType,
 		int(req.DealPriority),
+		int(req.MaxPerOrder),
 	)
 	if err != nil {
 		return &pb.CreateProductResponse{
@@ -83,6 +84,11 @@ func (h *ProductServiceHandler) UpdateProduct(ctx context.Context, req *pb.Updat
 	if req.FlashSaleEndTime != "" {
 		flashSaleEndTime = &req.FlashSaleEndTime
 	}
+	var maxPerOrder *int
+	if req.MaxPerOrder != nil {
+		value := int(*req.MaxPerOrder)
+		maxPerOrder = &value
+	}
 
 	product, err := h.productService.UpdateProduct(
 		req.Id,
@@ -101,6 +107,7 @@ func (h *ProductServiceHandler) UpdateProduct(ctx context.Context, req *pb.Updat
 		req.IsTopDeal,
 		req.DealType,
 		int(req.DealPriority),
+		maxPerOrder,
 	)
 	if err != nil {
 		return &pb.UpdateProductResponse{
@@ -222,6 +229,47 @@ func (h *ProductServiceHandler) GetDealsByType(ctx context.Context, req *pb.GetD
 	}, nil
 }
 
+// ReserveStock atomically decrements stock for an order
+func (h *ProductServiceHandler) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	err := h.productService.ReserveStock(convertStockItems(req.Items))
+	if err != nil {
+		return &pb.ReserveStockResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ReserveStockResponse{
+		Success: true,
+		Message: "Stock reserved successfully",
+	}, nil
+}
+
+// ReleaseStock returns previously reserved stock
+func (h *ProductServiceHandler) ReleaseStock(ctx context.Context, req *pb.ReleaseStockRequest) (*pb.ReleaseStockResponse, error) {
+	err := h.productService.ReleaseStock(convertStockItems(req.Items))
+	if err != nil {
+		return &pb.ReleaseStockResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ReleaseStockResponse{
+		Success: true,
+		Message: "Stock released successfully",
+	}, nil
+}
+
+// Helper function to merge stock items into per-product quantities
+func convertStockItems(items []*pb.StockItem) map[string]int {
+	quantities := make(map[string]int, len(items))
+	for _, item := range items {
+		quantities[item.ProductId] += int(item.Quantity)
+	}
+	return quantities
+}
+
 // Helper function to convert model to protobuf
 func convertToProductData(product *models.Product) *pb.ProductData {
 	data := &pb.ProductData{
@@ -246,6 +294,7 @@ func convertToProductData(product *models.Product) *pb.ProductData {
 		IsTopDeal:          product.IsTopDeal,
 		DealType:           product.DealType,
 		DealPriority:       int32(product.DealPriority),
+		MaxPerOrder:        int32(product.MaxPerOrder),
 	}
 
 	// Convert flash sale end time if present
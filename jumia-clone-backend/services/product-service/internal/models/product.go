@@ -25,6 +25,7 @@ type Product struct {
 	IsTopDeal          bool           `gorm:"default:false;index" json:"is_top_deal"`
 	DealType           string         `gorm:"type:varchar(50)" json:"deal_type"` // e.g., "top_deal", "clearance", "hot_deal"
 	DealPriority       int            `gorm:"default:0" json:"deal_priority"`    // For ordering deals
+	MaxPerOrder        int            `gorm:"default:0" json:"max_per_order"`    // Most units one customer may order at once; 0 uses the cart default
 	CreatedAt          time.Time      `json:"created_at"`
 	UpdatedAt          time.Time      `json:"updated_at"`
 	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
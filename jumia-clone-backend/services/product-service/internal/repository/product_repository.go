@@ -2,6 +2,8 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 
 	"jumia-clone-backend/services/product-service/internal/models"
 
@@ -20,8 +22,13 @@ type ProductRepository interface {
 	GetFlashSaleProducts(page, pageSize int) ([]*models.Product, int64, error)
 	GetTopDeals(page, pageSize int) ([]*models.Product, int64, error)
 	GetDealsByType(dealType string, page, pageSize int) ([]*models.Product, int64, error)
+	ReserveStock(quantities map[string]int) error
+	ReleaseStock(quantities map[string]int) error
 }
 
+// ErrInsufficientStock is returned when a reservation asks for more units than are available
+var ErrInsufficientStock = errors.New("insufficient stock")
+
 type productRepository struct {
 	db *gorm.DB
 }
@@ -201,3 +208,53 @@ func (r *productRepository) GetDealsByType(dealType string, page, pageSize int)
 
 	return products, total, err
 }
+
+// ReserveStock decrements stock for every product in a single transaction.
+// Each decrement is conditional on enough stock remaining, so concurrent
+// reservations can never drive stock below zero.
+func (r *productRepository) ReserveStock(quantities map[string]int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range sortedProductIDs(quantities) {
+			quantity := quantities[id]
+			result := tx.Model(&models.Product{}).
+				Where("id = ? AND is_active = ? AND stock >= ?", id, true, quantity).
+				Update("stock", gorm.Expr("stock - ?", quantity))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("%w for product %s", ErrInsufficientStock, id)
+			}
+		}
+		return nil
+	})
+}
+
+// ReleaseStock puts previously reserved units back into stock
+func (r *productRepository) ReleaseStock(quantities map[string]int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, id := range sortedProductIDs(quantities) {
+			result := tx.Model(&models.Product{}).
+				Where("id = ?", id).
+				Update("stock", gorm.Expr("stock + ?", quantities[id]))
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errors.New("product not found")
+			}
+		}
+		return nil
+	})
+}
+
+// sortedProductIDs returns product IDs in a stable order so that concurrent
+// transactions lock rows in the same sequence and cannot deadlock
+func sortedProductIDs(quantities map[string]int) []string {
+	ids := make([]string, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,15 +10,17 @@ import (
 )
 
 type ProductService interface {
-	CreateProduct(name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority int) (*models.Product, error)
+	CreateProduct(name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority, maxPerOrder int) (*models.Product, error)
 	GetProductByID(id string) (*models.Product, error)
-	UpdateProduct(id, name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority int) (*models.Product, error)
+	UpdateProduct(id, name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority int, maxPerOrder *int) (*models.Product, error)
 	DeleteProduct(id string) error
 	ListProducts(page, pageSize int) ([]*models.Product, int64, error)
 	SearchProducts(query string, page, pageSize int) ([]*models.Product, int64, error)
 	GetProductsByCategory(category string, page, pageSize int) ([]*models.Product, int64, error)
 	GetTopDeals(page, pageSize int) ([]*models.Product, int64, error)
 	GetDealsByType(dealType string, page, pageSize int) ([]*models.Product, int64, error)
+	ReserveStock(quantities map[string]int) error
+	ReleaseStock(quantities map[string]int) error
 }
 
 type productService struct {
@@ -28,7 +31,11 @@ func NewProductService(repo repository.ProductRepository) ProductService {
 	return &productService{repo: repo}
 }
 
-func (s *productService) CreateProduct(name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority int) (*models.Product, error) {
+func (s *productService) CreateProduct(name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority, maxPerOrder int) (*models.Product, error) {
+	if maxPerOrder < 0 {
+		return nil, errors.New("max_per_order cannot be negative")
+	}
+
 	product := &models.Product{
 		Name:               name,
 		Description:        description,
@@ -45,6 +52,7 @@ func (s *productService) CreateProduct(name, description, category, imageURL, br
 		IsTopDeal:          isTopDeal,
 		DealType:           dealType,
 		DealPriority:       dealPriority,
+		MaxPerOrder:        maxPerOrder,
 	}
 
 	// Parse flash sale end time if provided
@@ -65,7 +73,7 @@ func (s *productService) GetProductByID(id string) (*models.Product, error) {
 	return s.repo.GetByID(id)
 }
 
-func (s *productService) UpdateProduct(id, name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority int) (*models.Product, error) {
+func (s *productService) UpdateProduct(id, name, description, category, imageURL, brand string, price, discountPercentage float64, stock int, isFlashSale bool, flashSalePrice float64, flashSaleEndTime *string, initialStock int, isTopDeal bool, dealType string, dealPriority int, maxPerOrder *int) (*models.Product, error) {
 	product, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
@@ -108,6 +116,13 @@ func (s *productService) UpdateProduct(id, name, description, category, imageURL
 	product.DealType = dealType
 	product.DealPriority = dealPriority
 
+	if maxPerOrder != nil {
+		if *maxPerOrder < 0 {
+			return nil, errors.New("max_per_order cannot be negative")
+		}
+		product.MaxPerOrder = *maxPerOrder
+	}
+
 	// Parse flash sale end time if provided
 	if flashSaleEndTime != nil && *flashSaleEndTime != "" {
 		if endTime, err := parseTime(*flashSaleEndTime); err == nil {
@@ -184,6 +199,33 @@ func (s *productService) GetDealsByType(dealType string, page, pageSize int) ([]
 	return s.repo.GetDealsByType(dealType, page, pageSize)
 }
 
+func (s *productService) ReserveStock(quantities map[string]int) error {
+	if err := validateQuantities(quantities); err != nil {
+		return err
+	}
+	return s.repo.ReserveStock(quantities)
+}
+
+func (s *productService) ReleaseStock(quantities map[string]int) error {
+	if err := validateQuantities(quantities); err != nil {
+		return err
+	}
+	return s.repo.ReleaseStock(quantities)
+}
+
+// Helper function to validate stock adjustment quantities
+func validateQuantities(quantities map[string]int) error {
+	if len(quantities) == 0 {
+		return errors.New("no items to adjust")
+	}
+	for id, quantity := range quantities {
+		if quantity < 1 {
+			return fmt.Errorf("invalid quantity for product %s", id)
+		}
+	}
+	return nil
+}
+
 // Helper function to parse time string
 func parseTime(timeStr string) (time.Time, error) {
 	// Try parsing ISO 8601 format first
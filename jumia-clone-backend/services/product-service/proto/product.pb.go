@@ -39,6 +39,7 @@ type CreateProductRequest struct {
 	IsTopDeal          bool                   `protobuf:"varint,13,opt,name=is_top_deal,json=isTopDeal,proto3" json:"is_top_deal,omitempty"`
 	DealType           string                 `protobuf:"bytes,14,opt,name=deal_type,json=dealType,proto3" json:"deal_type,omitempty"`
 	DealPriority       int32                  `protobuf:"varint,15,opt,name=deal_priority,json=dealPriority,proto3" json:"deal_priority,omitempty"`
+	MaxPerOrder        int32                  `protobuf:"varint,16,opt,name=max_per_order,json=maxPerOrder,proto3" json:"max_per_order,omitempty"` // 0 uses the cart service default
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -178,6 +179,13 @@ func (x *CreateProductRequest) GetDealPriority() int32 {
 	return 0
 }
 
+func (x *CreateProductRequest) GetMaxPerOrder() int32 {
+	if x != nil {
+		return x.MaxPerOrder
+	}
+	return 0
+}
+
 type CreateProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -362,6 +370,7 @@ type UpdateProductRequest struct {
 	IsTopDeal          bool                   `protobuf:"varint,14,opt,name=is_top_deal,json=isTopDeal,proto3" json:"is_top_deal,omitempty"`
 	DealType           string                 `protobuf:"bytes,15,opt,name=deal_type,json=dealType,proto3" json:"deal_type,omitempty"`
 	DealPriority       int32                  `protobuf:"varint,16,opt,name=deal_priority,json=dealPriority,proto3" json:"deal_priority,omitempty"`
+	MaxPerOrder        *int32                 `protobuf:"varint,17,opt,name=max_per_order,json=maxPerOrder,proto3,oneof" json:"max_per_order,omitempty"` // Left unchanged when not set
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -508,6 +517,13 @@ func (x *UpdateProductRequest) GetDealPriority() int32 {
 	return 0
 }
 
+func (x *UpdateProductRequest) GetMaxPerOrder() int32 {
+	if x != nil && x.MaxPerOrder != nil {
+		return *x.MaxPerOrder
+	}
+	return 0
+}
+
 type UpdateProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -1415,6 +1431,253 @@ func (x *GetDealsByTypeResponse) GetMessage() string {
 	return ""
 }
 
+// Reserve Stock
+// All items are reserved together; if any product lacks stock nothing is reserved.
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StockItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_proto_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReserveStockRequest) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_proto_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReserveStockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReserveStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Release Stock
+type ReleaseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StockItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_proto_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ReleaseStockRequest) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReleaseStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockResponse) Reset() {
+	*x = ReleaseStockResponse{}
+	mi := &file_proto_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockResponse) ProtoMessage() {}
+
+func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReleaseStockResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReleaseStockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type StockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_proto_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockItem) ProtoMessage() {}
+
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_proto_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StockItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
 // Product Data
 type ProductData struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -1440,13 +1703,14 @@ type ProductData struct {
 	IsTopDeal          bool                   `protobuf:"varint,20,opt,name=is_top_deal,json=isTopDeal,proto3" json:"is_top_deal,omitempty"`
 	DealType           string                 `protobuf:"bytes,21,opt,name=deal_type,json=dealType,proto3" json:"deal_type,omitempty"`
 	DealPriority       int32                  `protobuf:"varint,22,opt,name=deal_priority,json=dealPriority,proto3" json:"deal_priority,omitempty"`
+	MaxPerOrder        int32                  `protobuf:"varint,23,opt,name=max_per_order,json=maxPerOrder,proto3" json:"max_per_order,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
 
 func (x *ProductData) Reset() {
 	*x = ProductData{}
-	mi := &file_proto_product_proto_msgTypes[20]
+	mi := &file_proto_product_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1458,7 +1722,7 @@ func (x *ProductData) String() string {
 func (*ProductData) ProtoMessage() {}
 
 func (x *ProductData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_product_proto_msgTypes[20]
+	mi := &file_proto_product_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,7 +1735,7 @@ func (x *ProductData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductData.ProtoReflect.Descriptor instead.
 func (*ProductData) Descriptor() ([]byte, []int) {
-	return file_proto_product_proto_rawDescGZIP(), []int{20}
+	return file_proto_product_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ProductData) GetId() string {
@@ -1628,11 +1892,18 @@ func (x *ProductData) GetDealPriority() int32 {
 	return 0
 }
 
+func (x *ProductData) GetMaxPerOrder() int32 {
+	if x != nil {
+		return x.MaxPerOrder
+	}
+	return 0
+}
+
 var File_proto_product_proto protoreflect.FileDescriptor
 
 const file_proto_product_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/product.proto\x12\aproduct\"\xfc\x03\n" +
+	"\x13proto/product.proto\x12\aproduct\"\xa0\x04\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
@@ -1649,7 +1920,8 @@ const file_proto_product_proto_rawDesc = "" +
 	"\rinitial_stock\x18\f \x01(\x05R\finitialStock\x12\x1e\n" +
 	"\vis_top_deal\x18\r \x01(\bR\tisTopDeal\x12\x1b\n" +
 	"\tdeal_type\x18\x0e \x01(\tR\bdealType\x12#\n" +
-	"\rdeal_priority\x18\x0f \x01(\x05R\fdealPriority\"[\n" +
+	"\rdeal_priority\x18\x0f \x01(\x05R\fdealPriority\x12\"\n" +
+	"\rmax_per_order\x18\x10 \x01(\x05R\vmaxPerOrder\"[\n" +
 	"\x15CreateProductResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
@@ -1659,7 +1931,7 @@ const file_proto_product_proto_rawDesc = "" +
 	"\x12GetProductResponse\x12.\n" +
 	"\aproduct\x18\x01 \x01(\v2\x14.product.ProductDataR\aproduct\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x8c\x04\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xc7\x04\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1677,7 +1949,9 @@ const file_proto_product_proto_rawDesc = "" +
 	"\rinitial_stock\x18\r \x01(\x05R\finitialStock\x12\x1e\n" +
 	"\vis_top_deal\x18\x0e \x01(\bR\tisTopDeal\x12\x1b\n" +
 	"\tdeal_type\x18\x0f \x01(\tR\bdealType\x12#\n" +
-	"\rdeal_priority\x18\x10 \x01(\x05R\fdealPriority\"{\n" +
+	"\rdeal_priority\x18\x10 \x01(\x05R\fdealPriority\x12'\n" +
+	"\rmax_per_order\x18\x11 \x01(\x05H\x00R\vmaxPerOrder\x88\x01\x01B\x10\n" +
+	"\x0e_max_per_order\"{\n" +
 	"\x15UpdateProductResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
@@ -1737,7 +2011,21 @@ const file_proto_product_proto_rawDesc = "" +
 	"\bproducts\x18\x01 \x03(\v2\x14.product.ProductDataR\bproducts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x18\n" +
 	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage\"\xde\x05\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"?\n" +
+	"\x13ReserveStockRequest\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.product.StockItemR\x05items\"J\n" +
+	"\x14ReserveStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"?\n" +
+	"\x13ReleaseStockRequest\x12(\n" +
+	"\x05items\x18\x01 \x03(\v2\x12.product.StockItemR\x05items\"J\n" +
+	"\x14ReleaseStockResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"F\n" +
+	"\tStockItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x82\x06\n" +
 	"\vProductData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1764,7 +2052,8 @@ const file_proto_product_proto_rawDesc = "" +
 	"\x14is_flash_sale_active\x18\x13 \x01(\bR\x11isFlashSaleActive\x12\x1e\n" +
 	"\vis_top_deal\x18\x14 \x01(\bR\tisTopDeal\x12\x1b\n" +
 	"\tdeal_type\x18\x15 \x01(\tR\bdealType\x12#\n" +
-	"\rdeal_priority\x18\x16 \x01(\x05R\fdealPriority2\xd1\x06\n" +
+	"\rdeal_priority\x18\x16 \x01(\x05R\fdealPriority\x12\"\n" +
+	"\rmax_per_order\x18\x17 \x01(\x05R\vmaxPerOrder2\xeb\a\n" +
 	"\x0eProductService\x12N\n" +
 	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x1e.product.CreateProductResponse\x12E\n" +
 	"\n" +
@@ -1776,7 +2065,9 @@ const file_proto_product_proto_rawDesc = "" +
 	"\x15GetProductsByCategory\x12%.product.GetProductsByCategoryRequest\x1a&.product.GetProductsByCategoryResponse\x12c\n" +
 	"\x14GetFlashSaleProducts\x12$.product.GetFlashSaleProductsRequest\x1a%.product.GetFlashSaleProductsResponse\x12H\n" +
 	"\vGetTopDeals\x12\x1b.product.GetTopDealsRequest\x1a\x1c.product.GetTopDealsResponse\x12Q\n" +
-	"\x0eGetDealsByType\x12\x1e.product.GetDealsByTypeRequest\x1a\x1f.product.GetDealsByTypeResponseB4Z2jumia-clone-backend/services/product-service/protob\x06proto3"
+	"\x0eGetDealsByType\x12\x1e.product.GetDealsByTypeRequest\x1a\x1f.product.GetDealsByTypeResponse\x12K\n" +
+	"\fReserveStock\x12\x1c.product.ReserveStockRequest\x1a\x1d.product.ReserveStockResponse\x12K\n" +
+	"\fReleaseStock\x12\x1c.product.ReleaseStockRequest\x1a\x1d.product.ReleaseStockResponseB4Z2jumia-clone-backend/services/product-service/protob\x06proto3"
 
 var (
 	file_proto_product_proto_rawDescOnce sync.Once
@@ -1790,7 +2081,7 @@ func file_proto_product_proto_rawDescGZIP() []byte {
 	return file_proto_product_proto_rawDescData
 }
 
-var file_proto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_proto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_proto_product_proto_goTypes = []any{
 	(*CreateProductRequest)(nil),          // 0: product.CreateProductRequest
 	(*CreateProductResponse)(nil),         // 1: product.CreateProductResponse
@@ -1812,42 +2103,53 @@ var file_proto_product_proto_goTypes = []any{
 	(*GetTopDealsResponse)(nil),           // 17: product.GetTopDealsResponse
 	(*GetDealsByTypeRequest)(nil),         // 18: product.GetDealsByTypeRequest
 	(*GetDealsByTypeResponse)(nil),        // 19: product.GetDealsByTypeResponse
-	(*ProductData)(nil),                   // 20: product.ProductData
+	(*ReserveStockRequest)(nil),           // 20: product.ReserveStockRequest
+	(*ReserveStockResponse)(nil),          // 21: product.ReserveStockResponse
+	(*ReleaseStockRequest)(nil),           // 22: product.ReleaseStockRequest
+	(*ReleaseStockResponse)(nil),          // 23: product.ReleaseStockResponse
+	(*StockItem)(nil),                     // 24: product.StockItem
+	(*ProductData)(nil),                   // 25: product.ProductData
 }
 var file_proto_product_proto_depIdxs = []int32{
-	20, // 0: product.GetProductResponse.product:type_name -> product.ProductData
-	20, // 1: product.UpdateProductResponse.product:type_name -> product.ProductData
-	20, // 2: product.ListProductsResponse.products:type_name -> product.ProductData
-	20, // 3: product.SearchProductsResponse.products:type_name -> product.ProductData
-	20, // 4: product.GetProductsByCategoryResponse.products:type_name -> product.ProductData
-	20, // 5: product.GetFlashSaleProductsResponse.products:type_name -> product.ProductData
-	20, // 6: product.GetTopDealsResponse.products:type_name -> product.ProductData
-	20, // 7: product.GetDealsByTypeResponse.products:type_name -> product.ProductData
-	0,  // 8: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
-	2,  // 9: product.ProductService.GetProduct:input_type -> product.GetProductRequest
-	4,  // 10: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
-	6,  // 11: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
-	8,  // 12: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
-	10, // 13: product.ProductService.SearchProducts:input_type -> product.SearchProductsRequest
-	12, // 14: product.ProductService.GetProductsByCategory:input_type -> product.GetProductsByCategoryRequest
-	14, // 15: product.ProductService.GetFlashSaleProducts:input_type -> product.GetFlashSaleProductsRequest
-	16, // 16: product.ProductService.GetTopDeals:input_type -> product.GetTopDealsRequest
-	18, // 17: product.ProductService.GetDealsByType:input_type -> product.GetDealsByTypeRequest
-	1,  // 18: product.ProductService.CreateProduct:output_type -> product.CreateProductResponse
-	3,  // 19: product.ProductService.GetProduct:output_type -> product.GetProductResponse
-	5,  // 20: product.ProductService.UpdateProduct:output_type -> product.UpdateProductResponse
-	7,  // 21: product.ProductService.DeleteProduct:output_type -> product.DeleteProductResponse
-	9,  // 22: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
-	11, // 23: product.ProductService.SearchProducts:output_type -> product.SearchProductsResponse
-	13, // 24: product.ProductService.GetProductsByCategory:output_type -> product.GetProductsByCategoryResponse
-	15, // 25: product.ProductService.GetFlashSaleProducts:output_type -> product.GetFlashSaleProductsResponse
-	17, // 26: product.ProductService.GetTopDeals:output_type -> product.GetTopDealsResponse
-	19, // 27: product.ProductService.GetDealsByType:output_type -> product.GetDealsByTypeResponse
-	18, // [18:28] is the sub-list for method output_type
-	8,  // [8:18] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	25, // 0: product.GetProductResponse.product:type_name -> product.ProductData
+	25, // 1: product.UpdateProductResponse.product:type_name -> product.ProductData
+	25, // 2: product.ListProductsResponse.products:type_name -> product.ProductData
+	25, // 3: product.SearchProductsResponse.products:type_name -> product.ProductData
+	25, // 4: product.GetProductsByCategoryResponse.products:type_name -> product.ProductData
+	25, // 5: product.GetFlashSaleProductsResponse.products:type_name -> product.ProductData
+	25, // 6: product.GetTopDealsResponse.products:type_name -> product.ProductData
+	25, // 7: product.GetDealsByTypeResponse.products:type_name -> product.ProductData
+	24, // 8: product.ReserveStockRequest.items:type_name -> product.StockItem
+	24, // 9: product.ReleaseStockRequest.items:type_name -> product.StockItem
+	0,  // 10: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
+	2,  // 11: product.ProductService.GetProduct:input_type -> product.GetProductRequest
+	4,  // 12: product.ProductService.UpdateProduct:input_type -> product.UpdateProductRequest
+	6,  // 13: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
+	8,  // 14: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
+	10, // 15: product.ProductService.SearchProducts:input_type -> product.SearchProductsRequest
+	12, // 16: product.ProductService.GetProductsByCategory:input_type -> product.GetProductsByCategoryRequest
+	14, // 17: product.ProductService.GetFlashSaleProducts:input_type -> product.GetFlashSaleProductsRequest
+	16, // 18: product.ProductService.GetTopDeals:input_type -> product.GetTopDealsRequest
+	18, // 19: product.ProductService.GetDealsByType:input_type -> product.GetDealsByTypeRequest
+	20, // 20: product.ProductService.ReserveStock:input_type -> product.ReserveStockRequest
+	22, // 21: product.ProductService.ReleaseStock:input_type -> product.ReleaseStockRequest
+	1,  // 22: product.ProductService.CreateProduct:output_type -> product.CreateProductResponse
+	3,  // 23: product.ProductService.GetProduct:output_type -> product.GetProductResponse
+	5,  // 24: product.ProductService.UpdateProduct:output_type -> product.UpdateProductResponse
+	7,  // 25: product.ProductService.DeleteProduct:output_type -> product.DeleteProductResponse
+	9,  // 26: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
+	11, // 27: product.ProductService.SearchProducts:output_type -> product.SearchProductsResponse
+	13, // 28: product.ProductService.GetProductsByCategory:output_type -> product.GetProductsByCategoryResponse
+	15, // 29: product.ProductService.GetFlashSaleProducts:output_type -> product.GetFlashSaleProductsResponse
+	17, // 30: product.ProductService.GetTopDeals:output_type -> product.GetTopDealsResponse
+	19, // 31: product.ProductService.GetDealsByType:output_type -> product.GetDealsByTypeResponse
+	21, // 32: product.ProductService.ReserveStock:output_type -> product.ReserveStockResponse
+	23, // 33: product.ProductService.ReleaseStock:output_type -> product.ReleaseStockResponse
+	22, // [22:34] is the sub-list for method output_type
+	10, // [10:22] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_product_proto_init() }
@@ -1855,13 +2157,14 @@ func file_proto_product_proto_init() {
 	if File_proto_product_proto != nil {
 		return
 	}
+	file_proto_product_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_product_proto_rawDesc), len(file_proto_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   21,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
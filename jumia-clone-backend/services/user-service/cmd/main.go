@@ -5,9 +5,12 @@ import (
 	"log"
 	"net"
 	"os"
+	"strings"
+	"time"
 
 	"jumia-clone-backend/services/user-service/internal/handler"
 	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/notifier"
 	"jumia-clone-backend/services/user-service/internal/repository"
 	"jumia-clone-backend/services/user-service/internal/service"
 	pb "jumia-clone-backend/services/user-service/proto"
@@ -36,16 +39,66 @@ func main() {
 	}
 
 	// Auto migrate the schema
-	if err := db.AutoMigrate(&models.User{}); err != nil {
+	if err := db.AutoMigrate(&models.User{}, &models.RefreshToken{}, &models.RevokedToken{}, &models.ActionToken{}, &models.LoginAttempt{}, &models.Address{}, &models.UserIdentity{}, &models.OIDCLoginState{}, &models.RecoveryCode{}, &models.SchemaMigration{}); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
+	if err := repository.RunDataMigrations(db); err != nil {
+		log.Fatalf("Failed to migrate data: %v", err)
+	}
+
 	log.Println("Database connected and migrated successfully")
 
+	// JWT signing keys. The development secret is public, so it is only
+	// used when APP_ENV=development says so; otherwise a missing key is fatal.
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" && os.Getenv("APP_ENV") == "development" {
+		log.Println("JWT_SECRET is not set, using the development secret")
+		jwtSecret = "your-secret-key-change-this-in-production"
+	}
+	keys, err := service.NewKeySet(service.KeyConfig{
+		Algorithm:            getEnv("JWT_ALGORITHM", service.AlgorithmHS256),
+		KeyID:                getEnv("JWT_KEY_ID", "default"),
+		Secret:               jwtSecret,
+		PrivateKeyFile:       os.Getenv("JWT_PRIVATE_KEY_FILE"),
+		VerificationKeyFiles: parseKeyList(os.Getenv("JWT_VERIFICATION_KEYS")),
+		PreviousSecrets:      parseKeyList(os.Getenv("JWT_PREVIOUS_SECRETS")),
+	})
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+
+	// External identity providers for OIDC login
+	var oidcConfigs []service.OIDCProviderConfig
+	if path := os.Getenv("OIDC_PROVIDERS_FILE"); path != "" {
+		if oidcConfigs, err = service.LoadOIDCProviders(path); err != nil {
+			log.Fatalf("Failed to load OIDC providers: %v", err)
+		}
+	}
+	oidcProviders, err := service.NewOIDCProviders(oidcConfigs)
+	if err != nil {
+		log.Fatalf("Failed to configure OIDC providers: %v", err)
+	}
+
+	// Account notifications (password resets) are written to a log until a
+	// mail provider is wired in
+	notify, err := notifier.NewLogNotifier(os.Getenv("NOTIFIER_LOG_FILE"))
+	if err != nil {
+		log.Fatalf("Failed to create notifier: %v", err)
+	}
+
 	// Initialize layers
 	userRepo := repository.NewUserRepository(db)
-	userSvc := service.NewUserService(userRepo)
-	userHandler := handler.NewUserServiceHandler(userSvc)
+	tokenRepo := repository.NewTokenRepository(db)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db)
+	identityRepo := repository.NewIdentityRepository(db)
+	userSvc := service.NewUserService(userRepo, tokenRepo, loginAttemptRepo, identityRepo, keys, oidcProviders, notify)
+	addressRepo := repository.NewAddressRepository(db)
+	addressSvc := service.NewAddressService(addressRepo, userRepo)
+	userHandler := handler.NewUserServiceHandler(userSvc, addressSvc)
+
+	// Periodically purge expired tokens and abandoned OIDC logins
+	go cleanupExpiredTokens(userSvc, time.Hour)
 
 	// gRPC server configuration
 	port := getEnv("GRPC_PORT", "50051")
@@ -74,3 +127,33 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// parseKeyList parses "kid1=value1,kid2=value2" into a map
+func parseKeyList(value string) map[string]string {
+	result := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		kid, val, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || kid == "" {
+			continue
+		}
+		result[kid] = val
+	}
+	return result
+}
+
+// cleanupExpiredTokens deletes expired token records on every tick
+func cleanupExpiredTokens(userSvc service.UserService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := userSvc.CleanupExpiredTokens()
+		if err != nil {
+			log.Printf("Failed to clean up expired tokens: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Cleaned up %d expired tokens", deleted)
+		}
+	}
+}
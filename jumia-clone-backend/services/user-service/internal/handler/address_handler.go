@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/service"
+	pb "jumia-clone-backend/services/user-service/proto"
+)
+
+// CreateAddress saves a new shipping address
+func (h *UserServiceHandler) CreateAddress(ctx context.Context, req *pb.CreateAddressRequest) (*pb.AddressResponse, error) {
+	address, err := h.addressService.CreateAddress(req.UserId, convertToAddressInput(req.Address))
+	if err != nil {
+		return &pb.AddressResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.AddressResponse{
+		Success: true,
+		Message: "Address created successfully",
+		Address: convertToAddressData(address),
+	}, nil
+}
+
+// GetAddress retrieves one of the user's addresses
+func (h *UserServiceHandler) GetAddress(ctx context.Context, req *pb.GetAddressRequest) (*pb.AddressResponse, error) {
+	address, err := h.addressService.GetAddress(req.AddressId, req.UserId)
+	if err != nil {
+		return &pb.AddressResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.AddressResponse{
+		Success: true,
+		Message: "Address retrieved successfully",
+		Address: convertToAddressData(address),
+	}, nil
+}
+
+// ListAddresses retrieves all of the user's addresses
+func (h *UserServiceHandler) ListAddresses(ctx context.Context, req *pb.ListAddressesRequest) (*pb.ListAddressesResponse, error) {
+	addresses, err := h.addressService.ListAddresses(req.UserId)
+	if err != nil {
+		return &pb.ListAddressesResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	data := make([]*pb.AddressData, 0, len(addresses))
+	for _, address := range addresses {
+		data = append(data, convertToAddressData(address))
+	}
+
+	return &pb.ListAddressesResponse{
+		Success:   true,
+		Message:   "Addresses retrieved successfully",
+		Addresses: data,
+	}, nil
+}
+
+// UpdateAddress changes one of the user's addresses
+func (h *UserServiceHandler) UpdateAddress(ctx context.Context, req *pb.UpdateAddressRequest) (*pb.AddressResponse, error) {
+	address, err := h.addressService.UpdateAddress(req.AddressId, req.UserId, convertToAddressInput(req.Address))
+	if err != nil {
+		return &pb.AddressResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.AddressResponse{
+		Success: true,
+		Message: "Address updated successfully",
+		Address: convertToAddressData(address),
+	}, nil
+}
+
+// DeleteAddress removes one of the user's addresses
+func (h *UserServiceHandler) DeleteAddress(ctx context.Context, req *pb.DeleteAddressRequest) (*pb.DeleteAddressResponse, error) {
+	if err := h.addressService.DeleteAddress(req.AddressId, req.UserId); err != nil {
+		return &pb.DeleteAddressResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DeleteAddressResponse{
+		Success: true,
+		Message: "Address deleted successfully",
+	}, nil
+}
+
+func convertToAddressInput(input *pb.AddressInput) service.AddressInput {
+	if input == nil {
+		return service.AddressInput{}
+	}
+	return service.AddressInput{
+		Label:         input.Label,
+		RecipientName: input.RecipientName,
+		Phone:         input.Phone,
+		Region:        input.Region,
+		City:          input.City,
+		Street:        input.Street,
+		IsDefault:     input.IsDefault,
+	}
+}
+
+func convertToAddressData(address *models.Address) *pb.AddressData {
+	return &pb.AddressData{
+		Id:            address.ID,
+		UserId:        address.UserID,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Region:        address.Region,
+		City:          address.City,
+		Street:        address.Street,
+		IsDefault:     address.IsDefault,
+		CreatedAt:     address.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     address.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"math"
 	"time"
 
+	"jumia-clone-backend/services/user-service/internal/models"
 	"jumia-clone-backend/services/user-service/internal/service"
 	pb "jumia-clone-backend/services/user-service/proto"
 )
@@ -11,13 +14,15 @@ import (
 // UserServiceHandler implements the gRPC UserServiceServer
 type UserServiceHandler struct {
 	pb.UnimplementedUserServiceServer
-	userService service.UserService
+	userService    service.UserService
+	addressService service.AddressService
 }
 
 // NewUserServiceHandler creates a new user service handler
-func NewUserServiceHandler(userService service.UserService) *UserServiceHandler {
+func NewUserServiceHandler(userService service.UserService, addressService service.AddressService) *UserServiceHandler {
 	return &UserServiceHandler{
-		userService: userService,
+		userService:    userService,
+		addressService: addressService,
 	}
 }
 
@@ -46,12 +51,23 @@ func (h *UserServiceHandler) Register(ctx context.Context, req *pb.RegisterReque
 
 // Login handles user authentication
 func (h *UserServiceHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
-	user, accessToken, refreshToken, err := h.userService.Login(req.Email, req.Password)
+	user, accessToken, refreshToken, err := h.userService.Login(req.Email, req.Password, req.IpAddress)
 	if err != nil {
-		return &pb.LoginResponse{
+		resp := &pb.LoginResponse{
 			Success: false,
 			Message: err.Error(),
-		}, nil
+		}
+		var locked *service.LoginLockedError
+		if errors.As(err, &locked) {
+			resp.RetryAfterSeconds = int32(math.Ceil(locked.RetryAfter.Seconds()))
+		}
+		var challenge *service.TOTPRequiredError
+		if errors.As(err, &challenge) {
+			resp.Success = true
+			resp.TotpRequired = true
+			resp.ChallengeToken = challenge.ChallengeToken
+		}
+		return resp, nil
 	}
 
 	return &pb.LoginResponse{
@@ -60,16 +76,7 @@ func (h *UserServiceHandler) Login(ctx context.Context, req *pb.LoginRequest) (*
 		RefreshToken: refreshToken,
 		Success:      true,
 		Message:      "Login successful",
-		User: &pb.UserData{
-			Id:        user.ID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Phone:     user.Phone,
-			Address:   user.Address,
-			CreatedAt: user.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
-		},
+		User:         convertToUserData(user),
 	}, nil
 }
 
@@ -86,16 +93,7 @@ func (h *UserServiceHandler) GetUser(ctx context.Context, req *pb.GetUserRequest
 	return &pb.GetUserResponse{
 		Success: true,
 		Message: "User retrieved successfully",
-		User: &pb.UserData{
-			Id:        user.ID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Phone:     user.Phone,
-			Address:   user.Address,
-			CreatedAt: user.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
-		},
+		User:    convertToUserData(user),
 	}, nil
 }
 
@@ -118,16 +116,7 @@ func (h *UserServiceHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserR
 	return &pb.UpdateUserResponse{
 		Success: true,
 		Message: "User updated successfully",
-		User: &pb.UserData{
-			Id:        user.ID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
-			Phone:     user.Phone,
-			Address:   user.Address,
-			CreatedAt: user.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
-		},
+		User:    convertToUserData(user),
 	}, nil
 }
 
@@ -149,7 +138,7 @@ func (h *UserServiceHandler) DeleteUser(ctx context.Context, req *pb.DeleteUserR
 
 // VerifyToken verifies JWT token
 func (h *UserServiceHandler) VerifyToken(ctx context.Context, req *pb.VerifyTokenRequest) (*pb.VerifyTokenResponse, error) {
-	userID, err := h.userService.VerifyToken(req.Token)
+	user, role, err := h.userService.VerifyToken(req.Token)
 	if err != nil {
 		return &pb.VerifyTokenResponse{
 			Valid:   false,
@@ -158,8 +147,572 @@ func (h *UserServiceHandler) VerifyToken(ctx context.Context, req *pb.VerifyToke
 	}
 
 	return &pb.VerifyTokenResponse{
-		Valid:   true,
-		UserId:  userID,
-		Message: "Token is valid",
+		Valid:         true,
+		UserId:        user.ID,
+		Role:          role,
+		EmailVerified: user.EmailVerified,
+		TotpEnabled:   user.TOTPEnabled(),
+		Message:       "Token is valid",
+	}, nil
+}
+
+// UpdateUserRole changes a user's role
+func (h *UserServiceHandler) UpdateUserRole(ctx context.Context, req *pb.UpdateUserRoleRequest) (*pb.UpdateUserRoleResponse, error) {
+	user, err := h.userService.UpdateUserRole(req.UserId, req.Role)
+	if err != nil {
+		return &pb.UpdateUserRoleResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.UpdateUserRoleResponse{
+		Success: true,
+		Message: "User role updated successfully",
+		User:    convertToUserData(user),
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair
+func (h *UserServiceHandler) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	user, accessToken, refreshToken, err := h.userService.RefreshToken(req.RefreshToken)
+	if err != nil {
+		return &pb.RefreshTokenResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.RefreshTokenResponse{
+		UserId:       user.ID,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		Success:      true,
+		Message:      "Token refreshed successfully",
+	}, nil
+}
+
+// Logout revokes the caller's tokens
+func (h *UserServiceHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	err := h.userService.Logout(req.Token, req.RefreshToken, req.AllSessions)
+	if err != nil {
+		return &pb.LogoutResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.LogoutResponse{
+		Success: true,
+		Message: "Logged out successfully",
+	}, nil
+}
+
+// GetJWKS returns the public token verification keys
+func (h *UserServiceHandler) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	keys := h.userService.GetPublicKeys()
+
+	jwks := make([]*pb.JSONWebKey, 0, len(keys))
+	for _, key := range keys {
+		jwks = append(jwks, &pb.JSONWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Alg: key.Alg,
+			Use: key.Use,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+
+	return &pb.GetJWKSResponse{
+		Success: true,
+		Message: "Keys retrieved successfully",
+		Keys:    jwks,
+	}, nil
+}
+
+// RequestPasswordReset sends a password reset token to the user's email
+func (h *UserServiceHandler) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	if err := h.userService.RequestPasswordReset(req.Email); err != nil {
+		return &pb.RequestPasswordResetResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.RequestPasswordResetResponse{
+		Success: true,
+		Message: "If an account exists for this email, a reset link has been sent",
+	}, nil
+}
+
+// ResetPassword sets a new password using a reset token
+func (h *UserServiceHandler) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	if err := h.userService.ResetPassword(req.Token, req.NewPassword); err != nil {
+		return &pb.ResetPasswordResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ResetPasswordResponse{
+		Success: true,
+		Message: "Password reset successfully",
+	}, nil
+}
+
+// ChangePassword changes a user's password and returns a fresh token pair
+func (h *UserServiceHandler) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	accessToken, refreshToken, err := h.userService.ChangePassword(req.UserId, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		return &pb.ChangePasswordResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ChangePasswordResponse{
+		Success:      true,
+		Message:      "Password changed successfully",
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// ChangeEmail sends a confirmation token to the requested new email
+func (h *UserServiceHandler) ChangeEmail(ctx context.Context, req *pb.ChangeEmailRequest) (*pb.ChangeEmailResponse, error) {
+	if err := h.userService.ChangeEmail(req.UserId, req.Password, req.NewEmail); err != nil {
+		return &pb.ChangeEmailResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ChangeEmailResponse{
+		Success: true,
+		Message: "A confirmation link has been sent to the new email",
+	}, nil
+}
+
+// ConfirmEmailChange applies a pending email change
+func (h *UserServiceHandler) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeRequest) (*pb.ConfirmEmailChangeResponse, error) {
+	user, err := h.userService.ConfirmEmailChange(req.Token)
+	if err != nil {
+		return &pb.ConfirmEmailChangeResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ConfirmEmailChangeResponse{
+		Success: true,
+		Message: "Email changed successfully",
+		User:    convertToUserData(user),
+	}, nil
+}
+
+// VerifyEmail confirms a user's email with a verification token
+func (h *UserServiceHandler) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	user, err := h.userService.VerifyEmail(req.Token)
+	if err != nil {
+		return &pb.VerifyEmailResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.VerifyEmailResponse{
+		Success: true,
+		Message: "Email verified successfully",
+		User:    convertToUserData(user),
+	}, nil
+}
+
+// ResendEmailVerification sends a new verification token
+func (h *UserServiceHandler) ResendEmailVerification(ctx context.Context, req *pb.ResendEmailVerificationRequest) (*pb.ResendEmailVerificationResponse, error) {
+	if err := h.userService.ResendEmailVerification(req.UserId); err != nil {
+		return &pb.ResendEmailVerificationResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ResendEmailVerificationResponse{
+		Success: true,
+		Message: "Verification email sent",
+	}, nil
+}
+
+// ListLoginAttempts returns the login attempt audit log
+func (h *UserServiceHandler) ListLoginAttempts(ctx context.Context, req *pb.ListLoginAttemptsRequest) (*pb.ListLoginAttemptsResponse, error) {
+	attempts, total, err := h.userService.ListLoginAttempts(
+		req.Email,
+		req.UserId,
+		req.IpAddress,
+		req.OnlyFailures,
+		int(req.Page),
+		int(req.PageSize),
+	)
+	if err != nil {
+		return &pb.ListLoginAttemptsResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ListLoginAttemptsResponse{
+		Success:  true,
+		Message:  "Login attempts retrieved successfully",
+		Attempts: convertToLoginAttemptData(attempts),
+		Total:    int32(total),
+	}, nil
+}
+
+// ListUsers returns a filtered, paginated list of users (admin only)
+func (h *UserServiceHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	filter := service.UserListFilter{
+		Email: req.Email,
+		Name:  req.Name,
+	}
+
+	switch req.Status {
+	case "":
+	case "active", "inactive":
+		active := req.Status == "active"
+		filter.IsActive = &active
+	default:
+		return &pb.ListUsersResponse{
+			Success: false,
+			Message: "status must be active or inactive",
+		}, nil
+	}
+
+	var err error
+	if filter.CreatedAfter, err = parseTimeFilter(req.CreatedAfter); err != nil {
+		return &pb.ListUsersResponse{
+			Success: false,
+			Message: "invalid created_after: " + err.Error(),
+		}, nil
+	}
+	if filter.CreatedBefore, err = parseTimeFilter(req.CreatedBefore); err != nil {
+		return &pb.ListUsersResponse{
+			Success: false,
+			Message: "invalid created_before: " + err.Error(),
+		}, nil
+	}
+
+	users, total, err := h.userService.ListUsers(filter, int(req.Page), int(req.PageSize))
+	if err != nil {
+		return &pb.ListUsersResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	data := make([]*pb.UserData, 0, len(users))
+	for _, user := range users {
+		data = append(data, convertToUserData(user))
+	}
+
+	return &pb.ListUsersResponse{
+		Success: true,
+		Message: "Users retrieved successfully",
+		Users:   data,
+		Total:   int32(total),
+	}, nil
+}
+
+// DeactivateUser blocks a user and revokes their sessions (admin only)
+func (h *UserServiceHandler) DeactivateUser(ctx context.Context, req *pb.DeactivateUserRequest) (*pb.DeactivateUserResponse, error) {
+	user, err := h.userService.DeactivateUser(req.UserId)
+	if err != nil {
+		return &pb.DeactivateUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DeactivateUserResponse{
+		Success: true,
+		Message: "User deactivated successfully",
+		User:    convertToUserData(user),
+	}, nil
+}
+
+// ReactivateUser restores a deactivated user (admin only)
+func (h *UserServiceHandler) ReactivateUser(ctx context.Context, req *pb.ReactivateUserRequest) (*pb.ReactivateUserResponse, error) {
+	user, err := h.userService.ReactivateUser(req.UserId)
+	if err != nil {
+		return &pb.ReactivateUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ReactivateUserResponse{
+		Success: true,
+		Message: "User reactivated successfully",
+		User:    convertToUserData(user),
+	}, nil
+}
+
+// ExportUserData returns everything stored about a user for a personal data export
+func (h *UserServiceHandler) ExportUserData(ctx context.Context, req *pb.ExportUserDataRequest) (*pb.ExportUserDataResponse, error) {
+	export, err := h.userService.ExportUserData(req.UserId)
+	if err != nil {
+		return &pb.ExportUserDataResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	addresses, err := h.addressService.ListAddresses(export.User.ID)
+	if err != nil {
+		return &pb.ExportUserDataResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	addressData := make([]*pb.AddressData, 0, len(addresses))
+	for _, address := range addresses {
+		addressData = append(addressData, convertToAddressData(address))
+	}
+
+	identities := make([]*pb.IdentityData, 0, len(export.Identities))
+	for _, identity := range export.Identities {
+		identities = append(identities, &pb.IdentityData{
+			Id:        identity.ID,
+			Provider:  identity.Provider,
+			Subject:   identity.Subject,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &pb.ExportUserDataResponse{
+		Success:       true,
+		Message:       "User data exported successfully",
+		User:          convertToUserData(export.User),
+		Addresses:     addressData,
+		LoginAttempts: convertToLoginAttemptData(export.LoginAttempts),
+		Identities:    identities,
+	}, nil
+}
+
+// EraseUser permanently deletes a user and their data
+func (h *UserServiceHandler) EraseUser(ctx context.Context, req *pb.EraseUserRequest) (*pb.EraseUserResponse, error) {
+	if err := h.userService.EraseUser(req.UserId); err != nil {
+		return &pb.EraseUserResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.EraseUserResponse{
+		Success: true,
+		Message: "User erased successfully",
+	}, nil
+}
+
+// ListOIDCProviders returns the identity providers users can log in with
+func (h *UserServiceHandler) ListOIDCProviders(ctx context.Context, req *pb.ListOIDCProvidersRequest) (*pb.ListOIDCProvidersResponse, error) {
+	providers := h.userService.ListOIDCProviders()
+
+	data := make([]*pb.OIDCProviderData, 0, len(providers))
+	for _, provider := range providers {
+		data = append(data, &pb.OIDCProviderData{
+			Name:        provider.Name,
+			DisplayName: provider.DisplayName,
+		})
+	}
+
+	return &pb.ListOIDCProvidersResponse{
+		Success:   true,
+		Message:   "Identity providers retrieved successfully",
+		Providers: data,
+	}, nil
+}
+
+// StartOIDCLogin returns the provider URL that begins an OIDC login
+func (h *UserServiceHandler) StartOIDCLogin(ctx context.Context, req *pb.StartOIDCLoginRequest) (*pb.StartOIDCLoginResponse, error) {
+	authURL, err := h.userService.StartOIDCLogin(req.Provider)
+	if err != nil {
+		return &pb.StartOIDCLoginResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.StartOIDCLoginResponse{
+		Success:          true,
+		Message:          "Login started",
+		AuthorizationUrl: authURL,
+	}, nil
+}
+
+// FinishOIDCLogin exchanges the provider's authorization code and logs the user in
+func (h *UserServiceHandler) FinishOIDCLogin(ctx context.Context, req *pb.FinishOIDCLoginRequest) (*pb.FinishOIDCLoginResponse, error) {
+	user, accessToken, refreshToken, created, err := h.userService.FinishOIDCLogin(req.Provider, req.Code, req.State, req.IpAddress)
+	if err != nil {
+		resp := &pb.FinishOIDCLoginResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+		var challenge *service.TOTPRequiredError
+		if errors.As(err, &challenge) {
+			resp.Success = true
+			resp.TotpRequired = true
+			resp.ChallengeToken = challenge.ChallengeToken
+		}
+		return resp, nil
+	}
+
+	return &pb.FinishOIDCLoginResponse{
+		Success:      true,
+		Message:      "Login successful",
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		User:         convertToUserData(user),
+		Created:      created,
+	}, nil
+}
+
+// EnrollTOTP starts two-factor authentication enrollment
+func (h *UserServiceHandler) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	secret, uri, err := h.userService.EnrollTOTP(req.UserId)
+	if err != nil {
+		return &pb.EnrollTOTPResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Success:    true,
+		Message:    "Scan the code with an authenticator app, then confirm with a code from the app",
+		Secret:     secret,
+		OtpauthUri: uri,
+	}, nil
+}
+
+// ConfirmTOTP enables two-factor authentication
+func (h *UserServiceHandler) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	codes, err := h.userService.ConfirmTOTP(req.UserId, req.Code)
+	if err != nil {
+		return &pb.ConfirmTOTPResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.ConfirmTOTPResponse{
+		Success:       true,
+		Message:       "Two-factor authentication enabled",
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// DisableTOTP turns two-factor authentication off
+func (h *UserServiceHandler) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	if err := h.userService.DisableTOTP(req.UserId, req.Password, req.Code); err != nil {
+		return &pb.DisableTOTPResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.DisableTOTPResponse{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+	}, nil
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes
+func (h *UserServiceHandler) RegenerateRecoveryCodes(ctx context.Context, req *pb.RegenerateRecoveryCodesRequest) (*pb.RegenerateRecoveryCodesResponse, error) {
+	codes, err := h.userService.RegenerateRecoveryCodes(req.UserId, req.Code)
+	if err != nil {
+		return &pb.RegenerateRecoveryCodesResponse{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pb.RegenerateRecoveryCodesResponse{
+		Success:       true,
+		Message:       "Recovery codes regenerated",
+		RecoveryCodes: codes,
 	}, nil
 }
+
+// VerifyTOTP completes a two-step login
+func (h *UserServiceHandler) VerifyTOTP(ctx context.Context, req *pb.VerifyTOTPRequest) (*pb.VerifyTOTPResponse, error) {
+	user, accessToken, refreshToken, err := h.userService.VerifyTOTP(req.ChallengeToken, req.Code, req.IpAddress)
+	if err != nil {
+		resp := &pb.VerifyTOTPResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+		var locked *service.LoginLockedError
+		if errors.As(err, &locked) {
+			resp.RetryAfterSeconds = int32(math.Ceil(locked.RetryAfter.Seconds()))
+		}
+		return resp, nil
+	}
+
+	return &pb.VerifyTOTPResponse{
+		Success:      true,
+		Message:      "Login successful",
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		User:         convertToUserData(user),
+	}, nil
+}
+
+// parseTimeFilter parses an optional RFC 3339 timestamp
+func parseTimeFilter(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Helper function to convert model to protobuf
+func convertToUserData(user *models.User) *pb.UserData {
+	return &pb.UserData{
+		Id:            user.ID,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		Address:       user.Address,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		IsActive:      user.IsActive,
+		TotpEnabled:   user.TOTPEnabled(),
+		CreatedAt:     user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     user.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func convertToLoginAttemptData(attempts []*models.LoginAttempt) []*pb.LoginAttemptData {
+	data := make([]*pb.LoginAttemptData, 0, len(attempts))
+	for _, attempt := range attempts {
+		data = append(data, &pb.LoginAttemptData{
+			Id:        attempt.ID,
+			Email:     attempt.Email,
+			UserId:    attempt.UserID,
+			IpAddress: attempt.IPAddress,
+			Success:   attempt.Success,
+			Reason:    attempt.Reason,
+			CreatedAt: attempt.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return data
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Address is a saved shipping address. Each user has at most one default.
+type Address struct {
+	ID            string    `gorm:"type:uuid;primary_key" json:"id"`
+	UserID        string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	Label         string    `gorm:"type:varchar(50)" json:"label"` // e.g. Home, Office
+	RecipientName string    `gorm:"type:varchar(200);not null" json:"recipient_name"`
+	Phone         string    `gorm:"type:varchar(20);not null" json:"phone"`
+	Region        string    `gorm:"type:varchar(100);not null" json:"region"` // County or region
+	City          string    `gorm:"type:varchar(100);not null" json:"city"`
+	Street        string    `gorm:"type:text;not null" json:"street"`
+	IsDefault     bool      `gorm:"default:false" json:"is_default"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating address
+func (a *Address) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the Address model
+func (Address) TableName() string {
+	return "addresses"
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserIdentity links a user to an account at an external OpenID Connect
+// provider. Subject is the provider's stable user identifier (the sub claim).
+type UserIdentity struct {
+	ID        string    `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	Provider  string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject   string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_identity_provider_subject" json:"subject"`
+	Email     string    `gorm:"type:varchar(255)" json:"email"` // Email reported by the provider at the last login
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating an identity
+func (i *UserIdentity) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the UserIdentity model
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// OIDCLoginState holds what is needed to finish an OpenID Connect login
+// started by a browser. Only a SHA-256 hash of the state parameter is stored,
+// and each state can be used once.
+type OIDCLoginState struct {
+	ID           string    `gorm:"type:uuid;primary_key" json:"id"`
+	Provider     string    `gorm:"type:varchar(50);not null" json:"provider"`
+	StateHash    string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Nonce        string    `gorm:"type:varchar(64);not null" json:"-"`
+	CodeVerifier string    `gorm:"type:varchar(128);not null" json:"-"` // PKCE verifier sent with the code exchange
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating a login state
+func (s *OIDCLoginState) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the OIDCLoginState model
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reasons recorded for failed login attempts
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureLocked             = "locked"
+	LoginFailureInvalidTOTP        = "invalid_totp"
+)
+
+// LockoutFailureReasons are the failures that count towards account and IP
+// lockout: wrong passwords and wrong second factor codes
+var LockoutFailureReasons = []string{LoginFailureInvalidCredentials, LoginFailureInvalidTOTP}
+
+// LoginAttempt is an audit record of a login attempt. Failed attempts also
+// drive account and IP lockout.
+type LoginAttempt struct {
+	ID        string    `gorm:"type:uuid;primary_key" json:"id"`
+	Email     string    `gorm:"type:varchar(255);not null;index" json:"email"`
+	UserID    string    `gorm:"type:varchar(36);index" json:"user_id"` // Empty when the email matches no user
+	IPAddress string    `gorm:"type:varchar(45);index" json:"ip_address"`
+	Success   bool      `gorm:"not null" json:"success"`
+	Reason    string    `gorm:"type:varchar(30)" json:"reason"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating login attempt
+func (a *LoginAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the LoginAttempt model
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// SchemaMigration records a one-off data migration that has been applied,
+// so it runs once per database
+type SchemaMigration struct {
+	ID        string    `gorm:"type:varchar(100);primary_key"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for the SchemaMigration model
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken tracks an issued refresh token so it can be rotated and revoked.
+// Its ID is the token's jti claim.
+type RefreshToken struct {
+	ID         string     `gorm:"type:uuid;primary_key" json:"id"`
+	UserID     string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	FamilyID   string     `gorm:"type:uuid;not null;index" json:"family_id"` // Shared by every token rotated from the same login
+	ExpiresAt  time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	ReplacedBy string     `gorm:"type:varchar(36)" json:"replaced_by"` // jti of the token issued when this one was rotated
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for the RefreshToken model
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// IsRevoked reports whether the token was rotated or revoked
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// RevokedToken is a denylist entry for an access token revoked before it
+// expired. Its ID is the token's jti claim; entries can be deleted once
+// ExpiresAt has passed because the token is rejected as expired anyway.
+type RevokedToken struct {
+	ID        string    `gorm:"type:varchar(36);primary_key" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for the RevokedToken model
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
+
+// Action token purposes
+const (
+	PurposePasswordReset = "password_reset"
+	PurposeEmailChange   = "email_change"
+	PurposeVerifyEmail   = "verify_email"
+)
+
+// ActionToken is a single-use token sent to a user to confirm an action such
+// as a password reset or email change. Only a SHA-256 hash of the token is stored.
+type ActionToken struct {
+	ID        string     `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Purpose   string     `gorm:"type:varchar(30);not null" json:"purpose"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Data      string     `gorm:"type:varchar(255)" json:"data"` // Purpose-specific payload, e.g. the new email
+	ExpiresAt time.Time  `gorm:"not null;index" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating action token
+func (t *ActionToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the ActionToken model
+func (ActionToken) TableName() string {
+	return "action_tokens"
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the user
+// has lost their authenticator. Only a SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        string     `gorm:"type:uuid;primary_key" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar(64);not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating a recovery code
+func (c *RecoveryCode) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// TableName specifies the table name for the RecoveryCode model
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
@@ -7,6 +7,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// User roles
+const (
+	RoleCustomer = "customer"
+	RoleSeller   = "seller"
+	RoleAdmin    = "admin"
+)
+
 // User represents a user in the system
 type User struct {
 	ID        string         `gorm:"type:uuid;primary_key" json:"id"`
@@ -16,10 +23,23 @@ type User struct {
 	Password  string         `gorm:"type:varchar(255);not null" json:"-"` // Never return password in JSON
 	Phone     string         `gorm:"type:varchar(20)" json:"phone"`
 	Address   string         `gorm:"type:text" json:"address"`
+	Role      string         `gorm:"type:varchar(20);not null;default:'customer'" json:"role"`
 	IsActive  bool           `gorm:"default:true" json:"is_active"`
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+
+	EmailVerified bool `gorm:"default:false" json:"email_verified"`
+
+	// TokenVersion is carried in every token and bumped to invalidate them
+	// all ("log out all sessions")
+	TokenVersion int `gorm:"not null;default:0" json:"-"`
+
+	// Two-factor authentication. TOTPSecret is set at enrollment but only
+	// required at login once the enrollment is confirmed (TOTPEnabledAt).
+	TOTPSecret    string     `gorm:"type:varchar(64)" json:"-"`
+	TOTPEnabledAt *time.Time `json:"-"`
+	TOTPLastStep  int64      `gorm:"default:0" json:"-"` // Time step of the last accepted code, so codes cannot be replayed
 }
 
 // BeforeCreate hook to generate UUID before creating user
@@ -34,3 +54,17 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 func (User) TableName() string {
 	return "users"
 }
+
+// TOTPEnabled reports whether login requires a second factor
+func (u *User) TOTPEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// IsValidRole checks whether role is one of the supported user roles
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleCustomer, RoleSeller, RoleAdmin:
+		return true
+	}
+	return false
+}
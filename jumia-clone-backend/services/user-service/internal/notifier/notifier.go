@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// Notifier delivers account messages such as password reset links to users
+type Notifier interface {
+	SendPasswordReset(email, token string, expiresAt time.Time) error
+	SendEmailChange(newEmail, token string, expiresAt time.Time) error
+	SendEmailVerification(email, token string, expiresAt time.Time) error
+}
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a notifier for local development that writes
+// messages to the given file, or to the service log if path is empty,
+// instead of sending them
+func NewLogNotifier(path string) (Notifier, error) {
+	var out io.Writer = os.Stderr
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		out = file
+	}
+
+	return &logNotifier{logger: log.New(out, "[notifier] ", log.LstdFlags)}, nil
+}
+
+// SendPasswordReset records the reset token for the given address
+func (n *logNotifier) SendPasswordReset(email, token string, expiresAt time.Time) error {
+	n.logger.Printf("password reset for %s: token=%s expires=%s", email, token, expiresAt.Format(time.RFC3339))
+	return nil
+}
+
+// SendEmailChange records the confirmation token for a new email address
+func (n *logNotifier) SendEmailChange(newEmail, token string, expiresAt time.Time) error {
+	n.logger.Printf("email change to %s: token=%s expires=%s", newEmail, token, expiresAt.Format(time.RFC3339))
+	return nil
+}
+
+// SendEmailVerification records the verification token for a new account
+func (n *logNotifier) SendEmailVerification(email, token string, expiresAt time.Time) error {
+	n.logger.Printf("email verification for %s: token=%s expires=%s", email, token, expiresAt.Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrAddressNotFound is returned when an address does not exist or belongs to another user
+var ErrAddressNotFound = errors.New("address not found")
+
+// AddressRepository defines methods for address data access
+type AddressRepository interface {
+	Create(address *models.Address) error
+	GetByID(id, userID string) (*models.Address, error)
+	ListByUser(userID string) ([]*models.Address, error)
+	Update(address *models.Address) error
+	Delete(id, userID string) error
+}
+
+type addressRepository struct {
+	db *gorm.DB
+}
+
+// NewAddressRepository creates a new address repository
+func NewAddressRepository(db *gorm.DB) AddressRepository {
+	return &addressRepository{db: db}
+}
+
+// Create saves a new address. A user's first address becomes the default,
+// and a new default replaces the previous one.
+func (r *addressRepository) Create(address *models.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Address{}).Where("user_id = ?", address.UserID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			address.IsDefault = true
+		}
+
+		if address.IsDefault {
+			if err := clearDefault(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Create(address).Error
+	})
+}
+
+// GetByID retrieves one of the user's addresses
+func (r *addressRepository) GetByID(id, userID string) (*models.Address, error) {
+	var address models.Address
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, err
+	}
+	return &address, nil
+}
+
+// ListByUser retrieves a user's addresses, default first
+func (r *addressRepository) ListByUser(userID string) ([]*models.Address, error) {
+	var addresses []*models.Address
+	err := r.db.Where("user_id = ?", userID).
+		Order("is_default DESC, created_at ASC").
+		Find(&addresses).Error
+	return addresses, err
+}
+
+// Update saves changes to an address, clearing the user's previous default
+// if this one becomes the default
+func (r *addressRepository) Update(address *models.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if address.IsDefault {
+			if err := clearDefault(tx, address.UserID); err != nil {
+				return err
+			}
+		}
+		return tx.Save(address).Error
+	})
+}
+
+// Delete removes an address. If it was the default, the user's oldest
+// remaining address takes over.
+func (r *addressRepository) Delete(id, userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var address models.Address
+		if err := tx.Where("id = ? AND user_id = ?", id, userID).First(&address).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAddressNotFound
+			}
+			return err
+		}
+
+		if err := tx.Delete(&address).Error; err != nil {
+			return err
+		}
+		if !address.IsDefault {
+			return nil
+		}
+
+		var next models.Address
+		err := tx.Where("user_id = ?", userID).Order("created_at ASC").Limit(1).Find(&next).Error
+		if err != nil || next.ID == "" {
+			return err
+		}
+		return tx.Model(&next).Update("is_default", true).Error
+	})
+}
+
+func clearDefault(tx *gorm.DB, userID string) error {
+	return tx.Model(&models.Address{}).
+		Where("user_id = ? AND is_default = ?", userID, true).
+		Update("is_default", false).Error
+}
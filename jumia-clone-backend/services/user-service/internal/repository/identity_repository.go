@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrIdentityNotFound is returned when no user is linked to an external identity
+	ErrIdentityNotFound = errors.New("identity not found")
+	// ErrLoginStateInvalid is returned for an unknown, expired or already used login state
+	ErrLoginStateInvalid = errors.New("invalid or expired login state")
+)
+
+// IdentityRepository defines methods for external identity data access
+type IdentityRepository interface {
+	GetByProviderSubject(provider, subject string) (*models.UserIdentity, error)
+	ListByUser(userID string) ([]*models.UserIdentity, error)
+	Create(identity *models.UserIdentity) error
+	CreateWithUser(user *models.User, identity *models.UserIdentity) error
+	UpdateEmail(id, email string) error
+	CreateLoginState(state *models.OIDCLoginState) error
+	ConsumeLoginState(provider, stateHash string) (*models.OIDCLoginState, error)
+}
+
+type identityRepository struct {
+	db *gorm.DB
+}
+
+// NewIdentityRepository creates a new identity repository
+func NewIdentityRepository(db *gorm.DB) IdentityRepository {
+	return &identityRepository{db: db}
+}
+
+// GetByProviderSubject retrieves the identity a provider knows by subject
+func (r *identityRepository) GetByProviderSubject(provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrIdentityNotFound
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// ListByUser retrieves the identities linked to a user, oldest first
+func (r *identityRepository) ListByUser(userID string) ([]*models.UserIdentity, error) {
+	var identities []*models.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error
+	return identities, err
+}
+
+// Create links an external identity to an existing user
+func (r *identityRepository) Create(identity *models.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+// CreateWithUser creates a new user and links the identity to them in one
+// transaction, so a failed link does not leave an orphaned account
+func (r *identityRepository) CreateWithUser(user *models.User, identity *models.UserIdentity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
+
+// UpdateEmail records the email the provider reported at the latest login
+func (r *identityRepository) UpdateEmail(id, email string) error {
+	return r.db.Model(&models.UserIdentity{}).Where("id = ?", id).Update("email", email).Error
+}
+
+// CreateLoginState stores the state of a login that was just started
+func (r *identityRepository) CreateLoginState(state *models.OIDCLoginState) error {
+	return r.db.Create(state).Error
+}
+
+// ConsumeLoginState deletes an unexpired login state and returns it. A state
+// can only be consumed once, even under concurrent use.
+func (r *identityRepository) ConsumeLoginState(provider, stateHash string) (*models.OIDCLoginState, error) {
+	var state models.OIDCLoginState
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state_hash = ? AND provider = ?", stateHash, provider).First(&state).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrLoginStateInvalid
+			}
+			return err
+		}
+
+		result := tx.Where("id = ? AND expires_at > ?", state.ID, time.Now()).Delete(&models.OIDCLoginState{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrLoginStateInvalid
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
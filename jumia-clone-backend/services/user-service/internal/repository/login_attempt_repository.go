@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// LoginAttemptFilter narrows a login attempt query; empty fields match everything
+type LoginAttemptFilter struct {
+	Email        string
+	UserID       string
+	IPAddress    string
+	OnlyFailures bool
+}
+
+// FailureSummary counts failed login attempts and when the latest happened
+type FailureSummary struct {
+	Count  int64
+	LastAt *time.Time
+}
+
+// LoginAttemptRepository defines methods for login attempt data access
+type LoginAttemptRepository interface {
+	Create(attempt *models.LoginAttempt) error
+	AccountFailures(email string, since time.Time) (*FailureSummary, error)
+	IPFailures(ipAddress string, since time.Time) (*FailureSummary, error)
+	List(filter LoginAttemptFilter, page, pageSize int) ([]*models.LoginAttempt, int64, error)
+	ListForUser(userID, email string) ([]*models.LoginAttempt, error)
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository creates a new login attempt repository
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+// Create records a login attempt
+func (r *loginAttemptRepository) Create(attempt *models.LoginAttempt) error {
+	return r.db.Create(attempt).Error
+}
+
+// AccountFailures summarises wrong password and code attempts for an email since the
+// given time. A successful login resets the count.
+func (r *loginAttemptRepository) AccountFailures(email string, since time.Time) (*FailureSummary, error) {
+	var lastSuccess models.LoginAttempt
+	err := r.db.Where("email = ? AND success = ? AND created_at > ?", email, true, since).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&lastSuccess).Error
+	if err != nil {
+		return nil, err
+	}
+	if lastSuccess.ID != "" {
+		since = lastSuccess.CreatedAt
+	}
+
+	return r.failures(r.db.Where("email = ?", email), since)
+}
+
+// IPFailures summarises wrong password and code attempts from an IP address since the
+// given time. Successful logins from the IP do not reset the count, so one
+// valid account cannot be used to keep guessing at others.
+func (r *loginAttemptRepository) IPFailures(ipAddress string, since time.Time) (*FailureSummary, error) {
+	return r.failures(r.db.Where("ip_address = ?", ipAddress), since)
+}
+
+func (r *loginAttemptRepository) failures(scope *gorm.DB, since time.Time) (*FailureSummary, error) {
+	var summary FailureSummary
+	err := scope.Model(&models.LoginAttempt{}).
+		Select("COUNT(*) AS count, MAX(created_at) AS last_at").
+		Where("success = ? AND reason IN ? AND created_at > ?", false, models.LockoutFailureReasons, since).
+		Scan(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// List retrieves paginated login attempts, newest first
+func (r *loginAttemptRepository) List(filter LoginAttemptFilter, page, pageSize int) ([]*models.LoginAttempt, int64, error) {
+	var attempts []*models.LoginAttempt
+	var total int64
+
+	offset := (page - 1) * pageSize
+
+	query := r.db.Model(&models.LoginAttempt{})
+	if filter.Email != "" {
+		query = query.Where("email = ?", filter.Email)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.IPAddress != "" {
+		query = query.Where("ip_address = ?", filter.IPAddress)
+	}
+	if filter.OnlyFailures {
+		query = query.Where("success = ?", false)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := query.Offset(offset).
+		Limit(pageSize).
+		Order("created_at DESC").
+		Find(&attempts).Error
+
+	return attempts, total, err
+}
+
+// ListForUser retrieves every login attempt made for the user's account,
+// including failures recorded only by email, newest first
+func (r *loginAttemptRepository) ListForUser(userID, email string) ([]*models.LoginAttempt, error) {
+	var attempts []*models.LoginAttempt
+	err := r.db.Where("user_id = ? OR email = ?", userID, email).
+		Order("created_at DESC").
+		Find(&attempts).Error
+	return attempts, err
+}
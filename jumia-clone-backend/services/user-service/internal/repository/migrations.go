@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dataMigration changes existing rows in a way AutoMigrate cannot
+type dataMigration struct {
+	id    string
+	apply func(tx *gorm.DB) error
+}
+
+var dataMigrations = []dataMigration{
+	{
+		// Accounts created before email verification existed were never sent
+		// a verification email. Treat them as verified so that turning on
+		// REQUIRE_VERIFIED_EMAIL does not block their checkout.
+		id: "backfill_email_verified",
+		apply: func(tx *gorm.DB) error {
+			return tx.Unscoped().Model(&models.User{}).Where("email_verified = ?", false).UpdateColumn("email_verified", true).Error
+		},
+	},
+}
+
+// RunDataMigrations applies the data migrations that have not been applied
+// to the database yet. Each one is recorded in the same transaction as its
+// changes, so it runs exactly once even if several instances start together.
+func RunDataMigrations(db *gorm.DB) error {
+	for _, m := range dataMigrations {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SchemaMigration{
+				ID:        m.id,
+				AppliedAt: time.Now(),
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return nil
+			}
+			return m.apply(tx)
+		})
+		if err != nil {
+			return fmt.Errorf("data migration %s: %w", m.id, err)
+		}
+	}
+	return nil
+}
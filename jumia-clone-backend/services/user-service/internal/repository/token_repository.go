@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrTokenReused is returned when a refresh token that was already rotated is presented again
+	ErrTokenReused = errors.New("refresh token has already been used")
+	// ErrActionTokenInvalid is returned when an action token is unknown, used or expired
+	ErrActionTokenInvalid = errors.New("token is invalid or has expired")
+	// ErrRecoveryCodeInvalid is returned when a recovery code is unknown or already used
+	ErrRecoveryCodeInvalid = errors.New("invalid recovery code")
+)
+
+// TokenRepository defines methods for refresh token data access
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	GetRefreshToken(id string) (*models.RefreshToken, error)
+	RotateRefreshToken(oldID string, next *models.RefreshToken) error
+	RevokeTokenFamily(familyID string) error
+	RevokeUserTokens(userID string) error
+	RevokeAccessToken(token *models.RevokedToken) error
+	IsAccessTokenRevoked(id string) (bool, error)
+	CreateActionToken(token *models.ActionToken) error
+	ConsumeActionToken(purpose, tokenHash string) (*models.ActionToken, error)
+	DeleteExpired(now time.Time) (int64, error)
+	ReplaceRecoveryCodes(userID string, codeHashes []string) error
+	ConsumeRecoveryCode(userID, codeHash string) error
+	DeleteRecoveryCodes(userID string) error
+}
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRepository creates a new token repository
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+// CreateRefreshToken stores a newly issued refresh token
+func (r *tokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshToken retrieves a refresh token by its jti
+func (r *tokenRepository) GetRefreshToken(id string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("id = ?", id).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("refresh token not found")
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks a refresh token as used and stores its successor
+// in one transaction. Only one caller can rotate a given token; any other
+// gets ErrTokenReused.
+func (r *tokenRepository) RotateRefreshToken(oldID string, next *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", oldID).
+			Updates(map[string]interface{}{
+				"revoked_at":  time.Now(),
+				"replaced_by": next.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrTokenReused
+		}
+
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeTokenFamily revokes every token descended from the same login
+func (r *tokenRepository) RevokeTokenFamily(familyID string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeUserTokens revokes every refresh token belonging to a user
+func (r *tokenRepository) RevokeUserTokens(userID string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAccessToken adds an access token to the denylist
+func (r *tokenRepository) RevokeAccessToken(token *models.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+// IsAccessTokenRevoked checks whether an access token is on the denylist
+func (r *tokenRepository) IsAccessTokenRevoked(id string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RevokedToken{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// CreateActionToken stores a new action token and invalidates any earlier
+// unused token the user holds for the same purpose
+func (r *tokenRepository) CreateActionToken(token *models.ActionToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ActionToken{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, token.Purpose).
+			Update("used_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// ConsumeActionToken marks an unused, unexpired action token as used and
+// returns it. A token can only be consumed once, even under concurrent use.
+func (r *tokenRepository) ConsumeActionToken(purpose, tokenHash string) (*models.ActionToken, error) {
+	var token models.ActionToken
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_hash = ? AND purpose = ?", tokenHash, purpose).First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrActionTokenInvalid
+			}
+			return err
+		}
+
+		now := time.Now()
+		result := tx.Model(&models.ActionToken{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", token.ID, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrActionTokenInvalid
+		}
+		token.UsedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteExpired removes denylist entries, refresh tokens, action tokens and
+// OIDC login states that have expired, since they are rejected regardless
+func (r *tokenRepository) DeleteExpired(now time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.RevokedToken{}, &models.RefreshToken{}, &models.ActionToken{}, &models.OIDCLoginState{}} {
+			result := tx.Where("expires_at < ?", now).Delete(model)
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted += result.RowsAffected
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// ReplaceRecoveryCodes deletes the user's recovery codes and stores new ones
+func (r *tokenRepository) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// ConsumeRecoveryCode marks one of the user's unused recovery codes as used.
+// A code can only be used once, even under concurrent use.
+func (r *tokenRepository) ConsumeRecoveryCode(userID, codeHash string) error {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecoveryCodeInvalid
+	}
+	return nil
+}
+
+// DeleteRecoveryCodes removes all of the user's recovery codes
+func (r *tokenRepository) DeleteRecoveryCodes(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
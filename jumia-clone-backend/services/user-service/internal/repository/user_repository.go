@@ -2,12 +2,16 @@ package repository
 
 import (
 	"errors"
+	"time"
 
 	"jumia-clone-backend/services/user-service/internal/models"
 
 	"gorm.io/gorm"
 )
 
+// ErrTOTPCodeUsed is returned when a TOTP code's time step was already used
+var ErrTOTPCodeUsed = errors.New("code has already been used")
+
 // UserRepository defines methods for user data access
 type UserRepository interface {
 	Create(user *models.User) error
@@ -16,6 +20,20 @@ type UserRepository interface {
 	Update(user *models.User) error
 	Delete(id string) error
 	FindAll() ([]*models.User, error)
+	List(filter UserFilter, page, pageSize int) ([]*models.User, int64, error)
+	SetActive(id string, active bool) error
+	GetByIDIncludeDeleted(id string) (*models.User, error)
+	Erase(id string) error
+	AdvanceTOTPStep(id string, step int64) error
+}
+
+// UserFilter narrows a user listing; zero values match everything
+type UserFilter struct {
+	Email         string // Substring match
+	Name          string // Substring match on first or last name
+	IsActive      *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
 }
 
 type userRepository struct {
@@ -63,9 +81,15 @@ func (r *userRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
 
-// Delete soft deletes a user (sets is_active to false)
+// Delete soft deletes a user (sets is_active to false and deleted_at).
+// Unlike deactivation this cannot be undone through the API.
 func (r *userRepository) Delete(id string) error {
-	return r.db.Model(&models.User{}).Where("id = ?", id).Update("is_active", false).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", id).Update("is_active", false).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.User{}).Error
+	})
 }
 
 // FindAll retrieves all active users
@@ -74,3 +98,107 @@ func (r *userRepository) FindAll() ([]*models.User, error) {
 	err := r.db.Where("is_active = ?", true).Find(&users).Error
 	return users, err
 }
+
+// List retrieves paginated users, active or not, newest first. Deleted users
+// are excluded.
+func (r *userRepository) List(filter UserFilter, page, pageSize int) ([]*models.User, int64, error) {
+	var users []*models.User
+	var total int64
+
+	offset := (page - 1) * pageSize
+
+	query := r.db.Model(&models.User{})
+	if filter.Email != "" {
+		query = query.Where("email ILIKE ?", "%"+filter.Email+"%")
+	}
+	if filter.Name != "" {
+		name := "%" + filter.Name + "%"
+		query = query.Where("first_name ILIKE ? OR last_name ILIKE ?", name, name)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := query.Offset(offset).
+		Limit(pageSize).
+		Order("created_at DESC").
+		Find(&users).Error
+
+	return users, total, err
+}
+
+// SetActive activates or deactivates a user. Deleted users are included, so
+// that they can be deactivated before they are erased.
+func (r *userRepository) SetActive(id string, active bool) error {
+	result := r.db.Unscoped().Model(&models.User{}).Where("id = ?", id).Update("is_active", active)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
+// GetByIDIncludeDeleted retrieves a user by ID even if they were deactivated
+// or deleted
+func (r *userRepository) GetByIDIncludeDeleted(id string) (*models.User, error) {
+	var user models.User
+	err := r.db.Unscoped().Where("id = ?", id).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Erase permanently deletes a user together with their addresses, tokens,
+// recovery codes, linked identities and login history. Login attempts are matched by email as well, since
+// failed attempts may not carry the user ID.
+func (r *userRepository) Erase(id string) error {
+	user, err := r.GetByIDIncludeDeleted(id)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&models.Address{}, &models.RefreshToken{}, &models.RevokedToken{}, &models.ActionToken{}, &models.UserIdentity{}, &models.RecoveryCode{}} {
+			if err := tx.Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("user_id = ? OR email = ?", id, user.Email).Delete(&models.LoginAttempt{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&models.User{}).Error
+	})
+}
+
+// AdvanceTOTPStep records step as the user's last accepted TOTP time step,
+// unless the same or a later step was already accepted
+func (r *userRepository) AdvanceTOTPStep(id string, step int64) error {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTOTPCodeUsed
+	}
+	return nil
+}
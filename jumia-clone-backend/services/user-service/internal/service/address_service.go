@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/repository"
+)
+
+// maxAddressesPerUser bounds how many addresses a user can save
+const maxAddressesPerUser = 20
+
+// AddressService defines business logic for saved shipping addresses
+type AddressService interface {
+	CreateAddress(userID string, input AddressInput) (*models.Address, error)
+	GetAddress(id, userID string) (*models.Address, error)
+	ListAddresses(userID string) ([]*models.Address, error)
+	UpdateAddress(id, userID string, input AddressInput) (*models.Address, error)
+	DeleteAddress(id, userID string) error
+}
+
+// AddressInput holds the editable fields of an address
+type AddressInput struct {
+	Label         string
+	RecipientName string
+	Phone         string
+	Region        string
+	City          string
+	Street        string
+	IsDefault     bool
+}
+
+type addressService struct {
+	repo  repository.AddressRepository
+	users repository.UserRepository
+}
+
+// NewAddressService creates a new address service
+func NewAddressService(repo repository.AddressRepository, users repository.UserRepository) AddressService {
+	return &addressService{repo: repo, users: users}
+}
+
+// CreateAddress saves a new address for an existing user
+func (s *addressService) CreateAddress(userID string, input AddressInput) (*models.Address, error) {
+	if err := validateAddress(input); err != nil {
+		return nil, err
+	}
+	if _, err := s.users.GetByID(userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxAddressesPerUser {
+		return nil, errors.New("address limit reached")
+	}
+
+	address := &models.Address{UserID: userID}
+	applyAddressInput(address, input)
+
+	if err := s.repo.Create(address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// GetAddress retrieves one of the user's addresses
+func (s *addressService) GetAddress(id, userID string) (*models.Address, error) {
+	return s.repo.GetByID(id, userID)
+}
+
+// ListAddresses retrieves all of the user's addresses, default first
+func (s *addressService) ListAddresses(userID string) ([]*models.Address, error) {
+	return s.repo.ListByUser(userID)
+}
+
+// UpdateAddress replaces the fields of one of the user's addresses. The
+// default can be moved to this address but not cleared; set another
+// address as default instead.
+func (s *addressService) UpdateAddress(id, userID string, input AddressInput) (*models.Address, error) {
+	if err := validateAddress(input); err != nil {
+		return nil, err
+	}
+
+	address, err := s.repo.GetByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasDefault := address.IsDefault
+	applyAddressInput(address, input)
+	address.IsDefault = wasDefault || input.IsDefault
+
+	if err := s.repo.Update(address); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+// DeleteAddress removes one of the user's addresses
+func (s *addressService) DeleteAddress(id, userID string) error {
+	return s.repo.Delete(id, userID)
+}
+
+func validateAddress(input AddressInput) error {
+	if strings.TrimSpace(input.RecipientName) == "" ||
+		strings.TrimSpace(input.Phone) == "" ||
+		strings.TrimSpace(input.Region) == "" ||
+		strings.TrimSpace(input.City) == "" ||
+		strings.TrimSpace(input.Street) == "" {
+		return errors.New("recipient name, phone, region, city and street are required")
+	}
+	return nil
+}
+
+func applyAddressInput(address *models.Address, input AddressInput) {
+	address.Label = strings.TrimSpace(input.Label)
+	address.RecipientName = strings.TrimSpace(input.RecipientName)
+	address.Phone = strings.TrimSpace(input.Phone)
+	address.Region = strings.TrimSpace(input.Region)
+	address.City = strings.TrimSpace(input.City)
+	address.Street = strings.TrimSpace(input.Street)
+	address.IsDefault = input.IsDefault
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported JWT signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// KeyConfig describes where the JWT signing and verification keys come from
+type KeyConfig struct {
+	Algorithm      string // HS256, RS256 or EdDSA
+	KeyID          string // kid of the current signing key
+	Secret         string // shared secret for HS256
+	PrivateKeyFile string // PEM private key for RS256 and EdDSA
+
+	// Retired keys that are still accepted for verification, by kid
+	VerificationKeyFiles map[string]string // PEM public keys
+	PreviousSecrets      map[string]string // HS256 secrets
+}
+
+// JWK is a public key in JSON Web Key form
+type JWK struct {
+	Kty string
+	Kid string
+	Alg string
+	Use string
+	N   string // RSA modulus
+	E   string // RSA exponent
+	Crv string // EdDSA curve
+	X   string // EdDSA public key
+}
+
+type jwtKey struct {
+	id     string
+	method jwt.SigningMethod
+	sign   interface{} // nil for keys that only verify
+	verify interface{}
+}
+
+// KeySet signs tokens with the current key and verifies them with any known
+// key, selected by the kid header, so keys can be rotated without
+// invalidating tokens that are still in flight
+type KeySet struct {
+	current *jwtKey
+	keys    map[string]*jwtKey
+}
+
+// NewKeySet loads the signing key and any retired verification keys
+func NewKeySet(cfg KeyConfig) (*KeySet, error) {
+	if cfg.KeyID == "" {
+		return nil, errors.New("signing key ID is required")
+	}
+
+	current, err := loadSigningKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &KeySet{
+		current: current,
+		keys:    map[string]*jwtKey{current.id: current},
+	}
+
+	for kid, secret := range cfg.PreviousSecrets {
+		if err := ks.add(&jwtKey{id: kid, method: jwt.SigningMethodHS256, verify: []byte(secret)}); err != nil {
+			return nil, err
+		}
+	}
+
+	for kid, path := range cfg.VerificationKeyFiles {
+		key, err := loadVerificationKey(kid, path)
+		if err != nil {
+			return nil, err
+		}
+		if err := ks.add(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return ks, nil
+}
+
+func (ks *KeySet) add(key *jwtKey) error {
+	if _, exists := ks.keys[key.id]; exists {
+		return fmt.Errorf("duplicate key ID %q", key.id)
+	}
+	ks.keys[key.id] = key
+	return nil
+}
+
+// sign signs the claims with the current key and sets its kid header
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.current.method, claims)
+	token.Header["kid"] = ks.current.id
+	return token.SignedString(ks.current.sign)
+}
+
+// keyFunc picks the verification key named by the token's kid header.
+// Tokens issued before kids were introduced are checked against the
+// current key.
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	key := ks.current
+	if kid, ok := token.Header["kid"].(string); ok {
+		if key, ok = ks.keys[kid]; !ok {
+			return nil, errors.New("unknown token signing key")
+		}
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("invalid token signing method")
+	}
+	return key.verify, nil
+}
+
+// PublicKeys returns the asymmetric verification keys as JWKs. Shared
+// HS256 secrets are never published.
+func (ks *KeySet) PublicKeys() []JWK {
+	jwks := make([]JWK, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		switch pub := key.verify.(type) {
+		case *rsa.PublicKey:
+			jwks = append(jwks, JWK{
+				Kty: "RSA",
+				Kid: key.id,
+				Alg: AlgorithmRS256,
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks = append(jwks, JWK{
+				Kty: "OKP",
+				Kid: key.id,
+				Alg: AlgorithmEdDSA,
+				Use: "sig",
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+
+	sort.Slice(jwks, func(i, j int) bool { return jwks[i].Kid < jwks[j].Kid })
+	return jwks
+}
+
+func loadSigningKey(cfg KeyConfig) (*jwtKey, error) {
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		if cfg.Secret == "" {
+			return nil, errors.New("JWT secret is required for HS256")
+		}
+		secret := []byte(cfg.Secret)
+		return &jwtKey{id: cfg.KeyID, method: jwt.SigningMethodHS256, sign: secret, verify: secret}, nil
+
+	case AlgorithmRS256, AlgorithmEdDSA:
+		if cfg.PrivateKeyFile == "" {
+			return nil, fmt.Errorf("private key file is required for %s", cfg.Algorithm)
+		}
+		block, err := readPEM(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		var parsed interface{}
+		if block.Type == "RSA PRIVATE KEY" {
+			parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		} else {
+			parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+
+		switch priv := parsed.(type) {
+		case *rsa.PrivateKey:
+			if cfg.Algorithm != AlgorithmRS256 {
+				break
+			}
+			return &jwtKey{id: cfg.KeyID, method: jwt.SigningMethodRS256, sign: priv, verify: &priv.PublicKey}, nil
+		case ed25519.PrivateKey:
+			if cfg.Algorithm != AlgorithmEdDSA {
+				break
+			}
+			return &jwtKey{id: cfg.KeyID, method: jwt.SigningMethodEdDSA, sign: priv, verify: priv.Public()}, nil
+		}
+		return nil, fmt.Errorf("private key does not match algorithm %s", cfg.Algorithm)
+
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", cfg.Algorithm)
+	}
+}
+
+func loadVerificationKey(kid, path string) (*jwtKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed interface{}
+	if block.Type == "RSA PUBLIC KEY" {
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %q: %w", kid, err)
+	}
+
+	switch pub := parsed.(type) {
+	case *rsa.PublicKey:
+		return &jwtKey{id: kid, method: jwt.SigningMethodRS256, verify: pub}, nil
+	case ed25519.PublicKey:
+		return &jwtKey{id: kid, method: jwt.SigningMethodEdDSA, verify: pub}, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type for %q", kid)
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
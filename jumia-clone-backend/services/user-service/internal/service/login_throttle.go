@@ -0,0 +1,99 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/repository"
+)
+
+// Login throttling policy
+const (
+	maxAccountFailures = 5  // wrong passwords or codes per email before it is locked
+	maxIPFailures      = 20 // wrong passwords or codes per IP address before it is blocked
+	loginFailureWindow = 15 * time.Minute
+	loginLockout       = 15 * time.Minute
+)
+
+// LoginLockedError is returned by Login while an account or IP address is
+// locked out after too many failed attempts
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	minutes := int(math.Ceil(e.RetryAfter.Minutes()))
+	return fmt.Sprintf("too many failed login attempts, try again in %d minute(s)", minutes)
+}
+
+// loginLockout returns how long the email or IP address must wait before
+// trying again, or zero if it is not locked
+func (s *userService) loginLockout(email, ipAddress string, now time.Time) (time.Duration, error) {
+	since := now.Add(-loginFailureWindow)
+
+	account, err := s.attempts.AccountFailures(email, since)
+	if err != nil {
+		return 0, err
+	}
+	wait := lockoutRemaining(account, maxAccountFailures, now)
+
+	if ipAddress != "" {
+		ip, err := s.attempts.IPFailures(ipAddress, since)
+		if err != nil {
+			return 0, err
+		}
+		if ipWait := lockoutRemaining(ip, maxIPFailures, now); ipWait > wait {
+			wait = ipWait
+		}
+	}
+
+	return wait, nil
+}
+
+// lockoutRemaining returns the time left on a lockout that started with the
+// latest failure once the limit was reached
+func lockoutRemaining(summary *repository.FailureSummary, limit int64, now time.Time) time.Duration {
+	if summary.Count < limit || summary.LastAt == nil {
+		return 0
+	}
+	if remaining := summary.LastAt.Add(loginLockout).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// recordLoginAttempt writes the audit record for a login attempt. Failures
+// to record are logged rather than failing the login.
+func (s *userService) recordLoginAttempt(email, userID, ipAddress string, success bool, reason string) {
+	attempt := &models.LoginAttempt{
+		Email:     email,
+		UserID:    userID,
+		IPAddress: ipAddress,
+		Success:   success,
+		Reason:    reason,
+	}
+	if err := s.attempts.Create(attempt); err != nil {
+		log.Printf("Failed to record login attempt for %s: %v", email, err)
+	}
+}
+
+// ListLoginAttempts returns login attempts for admins to review
+func (s *userService) ListLoginAttempts(email, userID, ipAddress string, onlyFailures bool, page, pageSize int) ([]*models.LoginAttempt, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	filter := repository.LoginAttemptFilter{
+		Email:        email,
+		UserID:       userID,
+		IPAddress:    ipAddress,
+		OnlyFailures: onlyFailures,
+	}
+	return s.attempts.List(filter, page, pageSize)
+}
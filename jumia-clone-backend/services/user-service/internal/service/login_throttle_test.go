@@ -0,0 +1,148 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/repository"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// attemptLog keeps login attempts in memory and counts failures the way the
+// real repository does
+type attemptLog struct {
+	repository.LoginAttemptRepository
+	attempts []*models.LoginAttempt
+}
+
+func (r *attemptLog) Create(attempt *models.LoginAttempt) error {
+	attempt.CreatedAt = time.Now()
+	r.attempts = append(r.attempts, attempt)
+	return nil
+}
+
+func (r *attemptLog) AccountFailures(email string, since time.Time) (*repository.FailureSummary, error) {
+	for _, attempt := range r.attempts {
+		if attempt.Email == email && attempt.Success && attempt.CreatedAt.After(since) {
+			since = attempt.CreatedAt
+		}
+	}
+	return r.failures(func(a *models.LoginAttempt) bool { return a.Email == email }, since), nil
+}
+
+func (r *attemptLog) IPFailures(ipAddress string, since time.Time) (*repository.FailureSummary, error) {
+	return r.failures(func(a *models.LoginAttempt) bool { return a.IPAddress == ipAddress }, since), nil
+}
+
+func (r *attemptLog) failures(match func(*models.LoginAttempt) bool, since time.Time) *repository.FailureSummary {
+	summary := &repository.FailureSummary{}
+	for _, attempt := range r.attempts {
+		if !match(attempt) || attempt.Success || !attempt.CreatedAt.After(since) {
+			continue
+		}
+		counted := false
+		for _, reason := range models.LockoutFailureReasons {
+			counted = counted || attempt.Reason == reason
+		}
+		if counted {
+			summary.Count++
+			summary.LastAt = &attempt.CreatedAt
+		}
+	}
+	return summary
+}
+
+// loginUserRepository holds the one user that logs in
+type loginUserRepository struct {
+	totpUserRepository
+	user *models.User
+}
+
+func (r *loginUserRepository) GetByEmail(email string) (*models.User, error) {
+	if email != r.user.Email {
+		return nil, errors.New("user not found")
+	}
+	return r.user, nil
+}
+
+func (r *loginUserRepository) GetByID(id string) (*models.User, error) {
+	if id != r.user.ID {
+		return nil, errors.New("user not found")
+	}
+	return r.user, nil
+}
+
+func (r *loginUserRepository) Update(user *models.User) error {
+	r.user = user
+	return nil
+}
+
+// openDenylist is a denylist with no revoked tokens
+type openDenylist struct {
+	repository.TokenRepository
+}
+
+func (openDenylist) IsAccessTokenRevoked(id string) (bool, error) {
+	return false, nil
+}
+
+func (openDenylist) RevokeUserTokens(userID string) error {
+	return nil
+}
+
+func TestWrongTOTPCodesLockLogin(t *testing.T) {
+	keys, err := NewKeySet(KeyConfig{Algorithm: AlgorithmHS256, KeyID: "test", Secret: "test-secret"})
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	password, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabledAt := time.Now()
+	user := &models.User{
+		ID:            "user-1",
+		Email:         "jane@example.com",
+		Password:      string(password),
+		TOTPSecret:    rfc6238Secret,
+		TOTPEnabledAt: &enabledAt,
+	}
+	s := &userService{
+		repo:     &loginUserRepository{user: user},
+		tokens:   openDenylist{},
+		attempts: &attemptLog{},
+		keys:     keys,
+	}
+
+	login := func() (string, error) {
+		_, _, _, err := s.Login(user.Email, "password123", "203.0.113.7")
+		var required *TOTPRequiredError
+		if errors.As(err, &required) {
+			return required.ChallengeToken, nil
+		}
+		return "", err
+	}
+
+	challenge, err := login()
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	wrongCode := totpCode([]byte("12345678901234567890"), time.Now().Unix()/totpPeriod+5)
+	for i := 0; i < maxAccountFailures; i++ {
+		if _, _, _, err := s.VerifyTOTP(challenge, wrongCode, "203.0.113.7"); !errors.Is(err, ErrInvalidTOTPCode) {
+			t.Fatalf("wrong code %d: err = %v, want ErrInvalidTOTPCode", i+1, err)
+		}
+	}
+
+	var locked *LoginLockedError
+	if _, _, _, err := s.VerifyTOTP(challenge, wrongCode, "203.0.113.7"); !errors.As(err, &locked) {
+		t.Errorf("VerifyTOTP after %d wrong codes: err = %v, want LoginLockedError", maxAccountFailures, err)
+	}
+	if _, err := login(); !errors.As(err, &locked) {
+		t.Errorf("Login after %d wrong codes: err = %v, want LoginLockedError", maxAccountFailures, err)
+	}
+}
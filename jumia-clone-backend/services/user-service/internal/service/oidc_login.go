@@ -0,0 +1,177 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/repository"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ListOIDCProviders returns the providers users can log in with
+func (s *userService) ListOIDCProviders() []OIDCProviderInfo {
+	return s.oidc.List()
+}
+
+// StartOIDCLogin begins an authorization code login with PKCE and returns
+// the provider URL to send the browser to. The state, nonce and code
+// verifier are kept server-side until the browser comes back.
+func (s *userService) StartOIDCLogin(provider string) (string, error) {
+	p, err := s.oidc.get(provider)
+	if err != nil {
+		return "", err
+	}
+
+	state, stateHash, err := newActionToken()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := p.authorizationURL(state, nonce, pkceChallenge(verifier))
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.identities.CreateLoginState(&models.OIDCLoginState{
+		Provider:     provider,
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(oidcLoginTTL),
+	}); err != nil {
+		return "", err
+	}
+
+	return authURL, nil
+}
+
+// FinishOIDCLogin completes a login when the provider redirects back with
+// an authorization code. The user linked to the provider identity is logged
+// in; an unknown identity is linked to the account with the same verified
+// email, or a new account is created. Reports whether an account was created.
+// Accounts with two-factor authentication get a TOTPRequiredError as from Login.
+func (s *userService) FinishOIDCLogin(provider, code, state, ipAddress string) (*models.User, string, string, bool, error) {
+	p, err := s.oidc.get(provider)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	if code == "" || state == "" {
+		return nil, "", "", false, errors.New("code and state are required")
+	}
+
+	loginState, err := s.identities.ConsumeLoginState(provider, hashActionToken(state))
+	if err != nil {
+		return nil, "", "", false, err
+	}
+
+	rawIDToken, err := p.exchange(code, loginState.CodeVerifier)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	claims, err := p.verifyIDToken(rawIDToken, loginState.Nonce)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+
+	user, created, err := s.userForIdentity(provider, claims)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	if user.TOTPEnabled() {
+		return nil, "", "", false, s.totpChallenge(user)
+	}
+	s.recordLoginAttempt(user.Email, user.ID, ipAddress, true, "")
+
+	accessToken, refreshToken, err := s.issueTokenPair(user)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+
+	return user, accessToken, refreshToken, created, nil
+}
+
+// userForIdentity finds the user linked to a provider identity, linking or
+// creating one on first login
+func (s *userService) userForIdentity(provider string, claims *oidcClaims) (*models.User, bool, error) {
+	identity, err := s.identities.GetByProviderSubject(provider, claims.Subject)
+	if err == nil {
+		user, err := s.repo.GetByID(identity.UserID)
+		if err != nil {
+			return nil, false, errors.New("account is not active")
+		}
+		if claims.Email != "" && claims.Email != identity.Email {
+			if err := s.identities.UpdateEmail(identity.ID, claims.Email); err != nil {
+				log.Printf("Failed to update email of identity %s: %v", identity.ID, err)
+			}
+		}
+		return user, false, nil
+	}
+	if !errors.Is(err, repository.ErrIdentityNotFound) {
+		return nil, false, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, false, errors.New("identity provider did not return a verified email")
+	}
+
+	identity = &models.UserIdentity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+
+	if existing, _ := s.repo.GetByEmail(claims.Email); existing != nil {
+		// Otherwise whoever registered the address here first, without
+		// proving they own it, would share the account with its real owner
+		if !existing.EmailVerified {
+			return nil, false, errors.New("an account with this email already exists; log in with your password and verify your email first")
+		}
+		identity.UserID = existing.ID
+		if err := s.identities.Create(identity); err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	// The account has no usable password until the user sets one through
+	// a password reset
+	password, err := randomToken()
+	if err != nil {
+		return nil, false, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, false, err
+	}
+
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(strings.TrimSpace(claims.Name), " ")
+	}
+
+	user := &models.User{
+		FirstName:     firstName,
+		LastName:      lastName,
+		Email:         claims.Email,
+		Password:      string(hashedPassword),
+		Role:          models.RoleCustomer,
+		IsActive:      true,
+		EmailVerified: true,
+	}
+	if err := s.identities.CreateWithUser(user, identity); err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
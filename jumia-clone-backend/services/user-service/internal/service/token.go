@@ -0,0 +1,122 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Token types carried in the typ claim
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+
+	// tokenTypeTOTPChallenge is issued after the password step of a login
+	// that still needs a second factor
+	tokenTypeTOTPChallenge = "totp_challenge"
+)
+
+const (
+	accessTokenTTL   = 24 * time.Hour
+	refreshTokenTTL  = 7 * 24 * time.Hour
+	passwordResetTTL = time.Hour
+	emailChangeTTL   = 24 * time.Hour
+	verifyEmailTTL   = 48 * time.Hour
+	oidcLoginTTL     = 10 * time.Minute
+	totpChallengeTTL = 5 * time.Minute
+)
+
+// tokenClaims holds the claims the service reads back from a token
+type tokenClaims struct {
+	UserID    string
+	Role      string
+	Type      string
+	ID        string
+	Version   int // User's token version when issued; 0 for tokens without a ver claim
+	ExpiresAt time.Time
+}
+
+// generateToken creates a signed JWT for a user and returns it with its jti
+func (ks *KeySet) generateToken(user *models.User, tokenType string, expiration time.Duration) (string, string, error) {
+	jti := uuid.New().String()
+	claims := jwt.MapClaims{
+		"user_id": user.ID,
+		"role":    user.Role,
+		"typ":     tokenType,
+		"ver":     user.TokenVersion,
+		"jti":     jti,
+		"exp":     time.Now().Add(expiration).Unix(),
+		"iat":     time.Now().Unix(),
+	}
+
+	signed, err := ks.sign(claims)
+	if err != nil {
+		return "", "", err
+	}
+	return signed, jti, nil
+}
+
+// parseToken validates a JWT's signature and expiry and checks that it is
+// of the expected type
+func (ks *KeySet) parseToken(tokenString, tokenType string) (*tokenClaims, error) {
+	token, err := jwt.Parse(tokenString, ks.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+	if typ, _ := claims["typ"].(string); typ != tokenType {
+		return nil, errors.New("invalid token type")
+	}
+
+	result := &tokenClaims{UserID: userID, Type: tokenType}
+	result.ID, _ = claims["jti"].(string)
+	result.Role, _ = claims["role"].(string)
+	if ver, ok := claims["ver"].(float64); ok {
+		result.Version = int(ver)
+	}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		result.ExpiresAt = exp.Time
+	}
+	return result, nil
+}
+
+// newActionToken returns a random single-use token and the hash to store for it
+func newActionToken() (string, string, error) {
+	token, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	return token, hashActionToken(token), nil
+}
+
+// randomToken returns 32 random bytes, base64url encoded
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashActionToken returns the stored form of an action token
+func hashActionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/repository"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TOTP parameters (RFC 6238 defaults, which every authenticator app supports)
+const (
+	totpIssuer        = "Jumia"
+	totpDigits        = 6
+	totpPeriod        = 30 // seconds
+	totpSkew          = 1  // time steps accepted either side of the current one, for clock drift
+	recoveryCodeCount = 10
+)
+
+// ErrInvalidTOTPCode is returned for a wrong, expired or reused second factor code
+var ErrInvalidTOTPCode = errors.New("invalid two-factor authentication code")
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPRequiredError is returned by Login when the password was correct but
+// the account has two-factor authentication. The login is completed by
+// VerifyTOTP with the challenge token.
+type TOTPRequiredError struct {
+	ChallengeToken string
+}
+
+func (e *TOTPRequiredError) Error() string {
+	return "two-factor authentication required"
+}
+
+// EnrollTOTP generates a new TOTP secret for the user and returns it with
+// an otpauth:// URI for authenticator apps. The secret is not required at
+// login until ConfirmTOTP proves the app was set up.
+func (s *userService) EnrollTOTP(userID string) (string, string, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.TOTPEnabled() {
+		return "", "", errors.New("two-factor authentication is already enabled")
+	}
+
+	key := make([]byte, 20)
+	if _, err := rand.Read(key); err != nil {
+		return "", "", err
+	}
+	user.TOTPSecret = totpEncoding.EncodeToString(key)
+	if err := s.repo.Update(user); err != nil {
+		return "", "", err
+	}
+
+	return user.TOTPSecret, totpURI(user.TOTPSecret, user.Email), nil
+}
+
+// ConfirmTOTP enables two-factor authentication once the user enters a
+// code from their authenticator, and returns a fresh set of recovery codes.
+// The recovery codes are only ever shown here.
+func (s *userService) ConfirmTOTP(userID, code string) ([]string, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled() {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("two-factor authentication enrollment has not been started")
+	}
+
+	if err := s.checkTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user.TOTPEnabledAt = &now
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication off. Both the password and a
+// current code (or recovery code) are required.
+func (s *userService) DisableTOTP(userID, password, code string) error {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled() {
+		return errors.New("two-factor authentication is not enabled")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return errors.New("password is incorrect")
+	}
+	if err := s.verifySecondFactor(user, code); err != nil {
+		return err
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	if err := s.repo.Update(user); err != nil {
+		return err
+	}
+	return s.tokens.DeleteRecoveryCodes(user.ID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, invalidating
+// the old ones
+func (s *userService) RegenerateRecoveryCodes(userID, code string) ([]string, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled() {
+		return nil, errors.New("two-factor authentication is not enabled")
+	}
+	if err := s.checkTOTP(user, code); err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(user.ID)
+}
+
+// VerifyTOTP completes a login that Login answered with a challenge. The
+// code may be a TOTP code or a recovery code. Wrong codes count towards the
+// same lockout as wrong passwords.
+func (s *userService) VerifyTOTP(challengeToken, code, ipAddress string) (*models.User, string, string, error) {
+	claims, err := s.keys.parseToken(challengeToken, tokenTypeTOTPChallenge)
+	if err != nil {
+		return nil, "", "", errors.New("invalid or expired challenge")
+	}
+	revoked, err := s.tokens.IsAccessTokenRevoked(claims.ID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if revoked {
+		return nil, "", "", errors.New("invalid or expired challenge")
+	}
+
+	user, err := s.repo.GetByID(claims.UserID)
+	if err != nil || !user.TOTPEnabled() || claims.Version != user.TokenVersion {
+		return nil, "", "", errors.New("invalid or expired challenge")
+	}
+
+	wait, err := s.loginLockout(user.Email, ipAddress, time.Now())
+	if err != nil {
+		return nil, "", "", err
+	}
+	if wait > 0 {
+		s.recordLoginAttempt(user.Email, user.ID, ipAddress, false, models.LoginFailureLocked)
+		return nil, "", "", &LoginLockedError{RetryAfter: wait}
+	}
+
+	if err := s.verifySecondFactor(user, code); err != nil {
+		if errors.Is(err, ErrInvalidTOTPCode) {
+			s.recordLoginAttempt(user.Email, user.ID, ipAddress, false, models.LoginFailureInvalidTOTP)
+		}
+		return nil, "", "", err
+	}
+
+	// A challenge completes a single login
+	if err := s.tokens.RevokeAccessToken(&models.RevokedToken{
+		ID:        claims.ID,
+		UserID:    user.ID,
+		ExpiresAt: claims.ExpiresAt,
+	}); err != nil {
+		return nil, "", "", err
+	}
+	s.recordLoginAttempt(user.Email, user.ID, ipAddress, true, "")
+
+	accessToken, refreshToken, err := s.issueTokenPair(user)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return user, accessToken, refreshToken, nil
+}
+
+// totpChallenge returns the TOTPRequiredError that pauses a login of a user
+// with two-factor authentication
+func (s *userService) totpChallenge(user *models.User) error {
+	challenge, _, err := s.keys.generateToken(user, tokenTypeTOTPChallenge, totpChallengeTTL)
+	if err != nil {
+		return err
+	}
+	return &TOTPRequiredError{ChallengeToken: challenge}
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code
+func (s *userService) verifySecondFactor(user *models.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totpDigits {
+		return s.checkTOTP(user, code)
+	}
+
+	err := s.tokens.ConsumeRecoveryCode(user.ID, hashActionToken(normalizeRecoveryCode(code)))
+	if errors.Is(err, repository.ErrRecoveryCodeInvalid) {
+		return ErrInvalidTOTPCode
+	}
+	return err
+}
+
+// checkTOTP validates a TOTP code against the user's secret. Each time step
+// is accepted once, so an observed code cannot be replayed.
+func (s *userService) checkTOTP(user *models.User, code string) error {
+	step, ok := matchTOTP(user.TOTPSecret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return ErrInvalidTOTPCode
+	}
+
+	if err := s.repo.AdvanceTOTPStep(user.ID, step); err != nil {
+		if errors.Is(err, repository.ErrTOTPCodeUsed) {
+			return ErrInvalidTOTPCode
+		}
+		return err
+	}
+	user.TOTPLastStep = step
+	return nil
+}
+
+// replaceRecoveryCodes generates and stores a new set of recovery codes
+func (s *userService) replaceRecoveryCodes(userID string) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(totpEncoding.EncodeToString(b))
+		codes = append(codes, code[:4]+"-"+code[4:])
+		hashes = append(hashes, hashActionToken(code))
+	}
+
+	if err := s.tokens.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode strips the formatting users may type with a recovery code
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// matchTOTP returns the time step a code is valid for, allowing for clock
+// drift of totpSkew steps
+func matchTOTP(secret, code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode computes the HOTP value (RFC 4226) for a time step
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// totpURI builds the otpauth:// URI that authenticator apps read from a QR code
+func totpURI(secret, email string) string {
+	query := url.Values{
+		"secret":    {secret},
+		"issuer":    {totpIssuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(totpDigits)},
+		"period":    {fmt.Sprint(totpPeriod)},
+	}
+	return "otpauth://totp/" + url.PathEscape(totpIssuer+":"+email) + "?" + query.Encode()
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/repository"
+)
+
+// rfc6238Secret is the SHA-1 test key of RFC 6238, "12345678901234567890"
+var rfc6238Secret = totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCodeRFC6238Vectors(t *testing.T) {
+	// RFC 6238 appendix B lists 8-digit codes; a 6-digit code is the same
+	// value modulo 10^6, so the last six digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	key := []byte("12345678901234567890")
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("totpCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestMatchTOTPSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := now.Unix() / totpPeriod
+	key := []byte("12345678901234567890")
+
+	tests := []struct {
+		name   string
+		offset int64
+		ok     bool
+	}{
+		{"current step", 0, true},
+		{"one step behind", -1, true},
+		{"one step ahead", 1, true},
+		{"two steps behind", -2, false},
+		{"two steps ahead", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := matchTOTP(rfc6238Secret, totpCode(key, current+tt.offset), now)
+			if ok != tt.ok {
+				t.Fatalf("matchTOTP ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && step != current+tt.offset {
+				t.Errorf("matchTOTP step = %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestMatchTOTPRejectsMalformedInput(t *testing.T) {
+	now := time.Unix(59, 0)
+	if _, ok := matchTOTP(rfc6238Secret, "94287082", now); ok {
+		t.Error("accepted an 8-digit code")
+	}
+	if _, ok := matchTOTP(rfc6238Secret, "", now); ok {
+		t.Error("accepted an empty code")
+	}
+	if _, ok := matchTOTP("not base32!", "287082", now); ok {
+		t.Error("accepted a code for an undecodable secret")
+	}
+}
+
+// totpUserRepository records the last accepted time step like the real
+// repository does
+type totpUserRepository struct {
+	repository.UserRepository
+	lastStep int64
+}
+
+func (r *totpUserRepository) AdvanceTOTPStep(id string, step int64) error {
+	if step <= r.lastStep {
+		return repository.ErrTOTPCodeUsed
+	}
+	r.lastStep = step
+	return nil
+}
+
+// recoveryCodeRepository holds unused recovery code hashes
+type recoveryCodeRepository struct {
+	repository.TokenRepository
+	unused map[string]bool
+}
+
+func (r *recoveryCodeRepository) ConsumeRecoveryCode(userID, codeHash string) error {
+	if !r.unused[codeHash] {
+		return repository.ErrRecoveryCodeInvalid
+	}
+	delete(r.unused, codeHash)
+	return nil
+}
+
+func TestVerifySecondFactorRejectsReplayedCode(t *testing.T) {
+	repo := &totpUserRepository{}
+	s := &userService{repo: repo}
+	user := &models.User{ID: "user-1", TOTPSecret: rfc6238Secret}
+
+	code := totpCode([]byte("12345678901234567890"), time.Now().Unix()/totpPeriod)
+	if err := s.verifySecondFactor(user, code); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.verifySecondFactor(user, code); !errors.Is(err, ErrInvalidTOTPCode) {
+		t.Fatalf("replay: err = %v, want ErrInvalidTOTPCode", err)
+	}
+	if user.TOTPLastStep != repo.lastStep {
+		t.Errorf("user.TOTPLastStep = %d, want %d", user.TOTPLastStep, repo.lastStep)
+	}
+}
+
+func TestVerifySecondFactorRejectsWrongCode(t *testing.T) {
+	s := &userService{repo: &totpUserRepository{}}
+	user := &models.User{ID: "user-1", TOTPSecret: rfc6238Secret}
+
+	code := totpCode([]byte("12345678901234567890"), time.Now().Unix()/totpPeriod+5)
+	if err := s.verifySecondFactor(user, code); !errors.Is(err, ErrInvalidTOTPCode) {
+		t.Fatalf("err = %v, want ErrInvalidTOTPCode", err)
+	}
+}
+
+func TestVerifySecondFactorRecoveryCodeOnce(t *testing.T) {
+	tokens := &recoveryCodeRepository{unused: map[string]bool{hashActionToken("abcd2345"): true}}
+	s := &userService{repo: &totpUserRepository{}, tokens: tokens}
+	user := &models.User{ID: "user-1", TOTPSecret: rfc6238Secret}
+
+	if err := s.verifySecondFactor(user, " ABCD-2345 "); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.verifySecondFactor(user, "abcd-2345"); !errors.Is(err, ErrInvalidTOTPCode) {
+		t.Fatalf("second use: err = %v, want ErrInvalidTOTPCode", err)
+	}
+}
@@ -2,34 +2,83 @@ package service
 
 import (
 	"errors"
+	"log"
+	"strings"
 	"time"
 
 	"jumia-clone-backend/services/user-service/internal/models"
+	"jumia-clone-backend/services/user-service/internal/notifier"
 	"jumia-clone-backend/services/user-service/internal/repository"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var jwtSecret = []byte("your-secret-key-change-this-in-production") // TODO: Move to environment variable
-
 // UserService defines business logic methods for user operations
 type UserService interface {
 	Register(firstName, lastName, email, password, phone string) (*models.User, error)
-	Login(email, password string) (*models.User, string, string, error)
+	Login(email, password, ipAddress string) (*models.User, string, string, error)
 	GetUserByID(id string) (*models.User, error)
 	UpdateUser(id, firstName, lastName, phone, address string) (*models.User, error)
 	DeleteUser(id string) error
-	VerifyToken(tokenString string) (string, error)
+	VerifyToken(tokenString string) (*models.User, string, error)
+	UpdateUserRole(id, role string) (*models.User, error)
+	RefreshToken(refreshToken string) (*models.User, string, string, error)
+	Logout(accessToken, refreshToken string, allSessions bool) error
+	CleanupExpiredTokens() (int64, error)
+	GetPublicKeys() []JWK
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
+	ChangePassword(id, currentPassword, newPassword string) (string, string, error)
+	ChangeEmail(id, password, newEmail string) error
+	ConfirmEmailChange(token string) (*models.User, error)
+	VerifyEmail(token string) (*models.User, error)
+	ResendEmailVerification(id string) error
+	ListLoginAttempts(email, userID, ipAddress string, onlyFailures bool, page, pageSize int) ([]*models.LoginAttempt, int64, error)
+	ListUsers(filter UserListFilter, page, pageSize int) ([]*models.User, int64, error)
+	DeactivateUser(id string) (*models.User, error)
+	ReactivateUser(id string) (*models.User, error)
+	ExportUserData(id string) (*UserDataExport, error)
+	EraseUser(id string) error
+	ListOIDCProviders() []OIDCProviderInfo
+	StartOIDCLogin(provider string) (string, error)
+	FinishOIDCLogin(provider, code, state, ipAddress string) (*models.User, string, string, bool, error)
+	EnrollTOTP(userID string) (string, string, error)
+	ConfirmTOTP(userID, code string) ([]string, error)
+	DisableTOTP(userID, password, code string) error
+	RegenerateRecoveryCodes(userID, code string) ([]string, error)
+	VerifyTOTP(challengeToken, code, ipAddress string) (*models.User, string, string, error)
+}
+
+// UserListFilter narrows an admin user listing; zero values match everything
+type UserListFilter struct {
+	Email         string
+	Name          string
+	IsActive      *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// UserDataExport is everything user service stores about a user
+type UserDataExport struct {
+	User          *models.User
+	LoginAttempts []*models.LoginAttempt
+	Identities    []*models.UserIdentity
 }
 
 type userService struct {
-	repo repository.UserRepository
+	repo       repository.UserRepository
+	tokens     repository.TokenRepository
+	attempts   repository.LoginAttemptRepository
+	identities repository.IdentityRepository
+	keys       *KeySet
+	oidc       *OIDCProviders
+	notifier   notifier.Notifier
 }
 
 // NewUserService creates a new user service
-func NewUserService(repo repository.UserRepository) UserService {
-	return &userService{repo: repo}
+func NewUserService(repo repository.UserRepository, tokens repository.TokenRepository, attempts repository.LoginAttemptRepository, identities repository.IdentityRepository, keys *KeySet, oidc *OIDCProviders, notify notifier.Notifier) UserService {
+	return &userService{repo: repo, tokens: tokens, attempts: attempts, identities: identities, keys: keys, oidc: oidc, notifier: notify}
 }
 
 // Register creates a new user account
@@ -53,6 +102,7 @@ func (s *userService) Register(firstName, lastName, email, password, phone strin
 		Email:     email,
 		Password:  string(hashedPassword),
 		Phone:     phone,
+		Role:      models.RoleCustomer,
 		IsActive:  true,
 	}
 
@@ -60,35 +110,141 @@ func (s *userService) Register(firstName, lastName, email, password, phone strin
 		return nil, err
 	}
 
+	// The account exists either way; the user can ask for a new link
+	if err := s.sendEmailVerification(user); err != nil {
+		log.Printf("Failed to send verification email to user %s: %v", user.ID, err)
+	}
+
 	return user, nil
 }
 
-// Login authenticates a user and returns JWT tokens
-func (s *userService) Login(email, password string) (*models.User, string, string, error) {
+// Login authenticates a user and returns JWT tokens. Every attempt is
+// recorded, and repeated failures lock out the email or IP address for a while.
+// Accounts with two-factor authentication get a TOTPRequiredError carrying
+// the challenge for VerifyTOTP instead of tokens.
+func (s *userService) Login(email, password, ipAddress string) (*models.User, string, string, error) {
+	wait, err := s.loginLockout(email, ipAddress, time.Now())
+	if err != nil {
+		return nil, "", "", err
+	}
+	if wait > 0 {
+		s.recordLoginAttempt(email, "", ipAddress, false, models.LoginFailureLocked)
+		return nil, "", "", &LoginLockedError{RetryAfter: wait}
+	}
+
 	// Get user by email
 	user, err := s.repo.GetByEmail(email)
 	if err != nil {
+		s.recordLoginAttempt(email, "", ipAddress, false, models.LoginFailureInvalidCredentials)
 		return nil, "", "", errors.New("invalid email or password")
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		s.recordLoginAttempt(email, user.ID, ipAddress, false, models.LoginFailureInvalidCredentials)
 		return nil, "", "", errors.New("invalid email or password")
 	}
 
-	// Generate access token (expires in 24 hours)
-	accessToken, err := generateToken(user.ID, 24*time.Hour)
+	// Success is only recorded once the second factor is verified too, so
+	// that correct passwords do not reset the lockout on wrong codes
+	if user.TOTPEnabled() {
+		return nil, "", "", s.totpChallenge(user)
+	}
+	s.recordLoginAttempt(email, user.ID, ipAddress, true, "")
+
+	accessToken, refreshToken, err := s.issueTokenPair(user)
 	if err != nil {
 		return nil, "", "", err
 	}
 
-	// Generate refresh token (expires in 7 days)
-	refreshToken, err := generateToken(user.ID, 7*24*time.Hour)
+	return user, accessToken, refreshToken, nil
+}
+
+// issueTokenPair creates an access token and the first refresh token of a
+// new family, as at the start of a session
+func (s *userService) issueTokenPair(user *models.User) (string, string, error) {
+	accessToken, err := s.issueAccessToken(user)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, record, err := s.newRefreshToken(user, uuid.New().String())
+	if err != nil {
+		return "", "", err
+	}
+	if err := s.tokens.CreateRefreshToken(record); err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// RefreshToken exchanges a refresh token for a new access and refresh token
+// pair. Each refresh token can be used once; presenting a used token again
+// is treated as theft and revokes every token from the same login.
+func (s *userService) RefreshToken(refreshToken string) (*models.User, string, string, error) {
+	claims, err := s.keys.parseToken(refreshToken, tokenTypeRefresh)
+	if err != nil {
+		return nil, "", "", errors.New("invalid refresh token")
+	}
+
+	stored, err := s.tokens.GetRefreshToken(claims.ID)
+	if err != nil {
+		return nil, "", "", errors.New("invalid refresh token")
+	}
+	if stored.IsRevoked() {
+		if err := s.tokens.RevokeTokenFamily(stored.FamilyID); err != nil {
+			return nil, "", "", err
+		}
+		return nil, "", "", errors.New("refresh token reuse detected, please log in again")
+	}
+
+	user, err := s.repo.GetByID(stored.UserID)
+	if err != nil {
+		return nil, "", "", errors.New("invalid refresh token")
+	}
+
+	accessToken, err := s.issueAccessToken(user)
 	if err != nil {
 		return nil, "", "", err
 	}
 
-	return user, accessToken, refreshToken, nil
+	newRefreshToken, record, err := s.newRefreshToken(user, stored.FamilyID)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if err := s.tokens.RotateRefreshToken(stored.ID, record); err != nil {
+		if errors.Is(err, repository.ErrTokenReused) {
+			_ = s.tokens.RevokeTokenFamily(stored.FamilyID)
+			return nil, "", "", errors.New("refresh token reuse detected, please log in again")
+		}
+		return nil, "", "", err
+	}
+
+	return user, accessToken, newRefreshToken, nil
+}
+
+// issueAccessToken creates a short-lived access token for a user
+func (s *userService) issueAccessToken(user *models.User) (string, error) {
+	token, _, err := s.keys.generateToken(user, tokenTypeAccess, accessTokenTTL)
+	return token, err
+}
+
+// newRefreshToken creates a refresh token in the given family and the
+// record that must be stored for it to be accepted later
+func (s *userService) newRefreshToken(user *models.User, familyID string) (string, *models.RefreshToken, error) {
+	token, jti, err := s.keys.generateToken(user, tokenTypeRefresh, refreshTokenTTL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	record := &models.RefreshToken{
+		ID:        jti,
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
+	return token, record, nil
 }
 
 // GetUserByID retrieves a user by ID
@@ -124,42 +280,392 @@ func (s *userService) UpdateUser(id, firstName, lastName, phone, address string)
 	return user, nil
 }
 
-// DeleteUser soft deletes a user
+// DeleteUser soft deletes a user and revokes their refresh tokens. Access
+// tokens stop working because VerifyToken only accepts active users.
 func (s *userService) DeleteUser(id string) error {
-	return s.repo.Delete(id)
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	return s.tokens.RevokeUserTokens(id)
 }
 
-// VerifyToken verifies JWT token and returns user ID
-func (s *userService) VerifyToken(tokenString string) (string, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid token signing method")
-		}
-		return jwtSecret, nil
-	})
+// UpdateUserRole changes the role of a user and logs them out of every session
+func (s *userService) UpdateUserRole(id, role string) (*models.User, error) {
+	if !models.IsValidRole(role) {
+		return nil, errors.New("invalid role")
+	}
+
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == role {
+		return user, nil
+	}
+
+	// Tokens carry the role they were issued with, so they are all revoked
+	// and the user logs in again with the new one
+	user.Role = role
+	if err := s.revokeAllSessions(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// VerifyToken verifies an access token and returns the user and their
+// current role. Tokens that were logged out, issued before a "log out all
+// sessions" or a role change, or that belong to a deleted user are rejected.
+func (s *userService) VerifyToken(tokenString string) (*models.User, string, error) {
+	claims, err := s.keys.parseToken(tokenString, tokenTypeAccess)
+	if err != nil {
+		return nil, "", err
+	}
+
+	revoked, err := s.tokens.IsAccessTokenRevoked(claims.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	if revoked {
+		return nil, "", errors.New("token has been revoked")
+	}
+
+	user, err := s.repo.GetByID(claims.UserID)
+	if err != nil {
+		return nil, "", errors.New("token has been revoked")
+	}
+	if claims.Version != user.TokenVersion {
+		return nil, "", errors.New("token has been revoked")
+	}
+
+	return user, user.Role, nil
+}
+
+// Logout revokes the given access token and, if provided, the refresh token
+// family it came with. With allSessions every token the user holds is revoked.
+func (s *userService) Logout(accessToken, refreshToken string, allSessions bool) error {
+	claims, err := s.keys.parseToken(accessToken, tokenTypeAccess)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if err := s.tokens.RevokeAccessToken(&models.RevokedToken{
+		ID:        claims.ID,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt,
+	}); err != nil {
+		return err
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID, ok := claims["user_id"].(string)
-		if !ok {
-			return "", errors.New("invalid token claims")
+	if refreshToken != "" {
+		refreshClaims, err := s.keys.parseToken(refreshToken, tokenTypeRefresh)
+		if err != nil || refreshClaims.UserID != claims.UserID {
+			return errors.New("invalid refresh token")
 		}
-		return userID, nil
+		if stored, err := s.tokens.GetRefreshToken(refreshClaims.ID); err == nil {
+			if err := s.tokens.RevokeTokenFamily(stored.FamilyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if allSessions {
+		user, err := s.repo.GetByID(claims.UserID)
+		if err != nil {
+			return err
+		}
+		return s.revokeAllSessions(user)
+	}
+
+	return nil
+}
+
+// revokeAllSessions invalidates every access and refresh token the user holds
+func (s *userService) revokeAllSessions(user *models.User) error {
+	user.TokenVersion++
+	if err := s.repo.Update(user); err != nil {
+		return err
+	}
+	return s.tokens.RevokeUserTokens(user.ID)
+}
+
+// CleanupExpiredTokens deletes denylist entries and refresh tokens past their expiry
+func (s *userService) CleanupExpiredTokens() (int64, error) {
+	return s.tokens.DeleteExpired(time.Now())
+}
+
+// GetPublicKeys returns the keys other services can use to verify tokens locally
+func (s *userService) GetPublicKeys() []JWK {
+	return s.keys.PublicKeys()
+}
+
+// RequestPasswordReset sends a single-use reset token to the user. Unknown
+// emails are ignored so the response does not reveal which accounts exist.
+func (s *userService) RequestPasswordReset(email string) error {
+	user, err := s.repo.GetByEmail(email)
+	if err != nil {
+		return nil
+	}
+
+	token, hash, err := newActionToken()
+	if err != nil {
+		return err
+	}
+
+	record := &models.ActionToken{
+		UserID:    user.ID,
+		Purpose:   models.PurposePasswordReset,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := s.tokens.CreateActionToken(record); err != nil {
+		return err
+	}
+
+	return s.notifier.SendPasswordReset(user.Email, token, record.ExpiresAt)
+}
+
+// ResetPassword sets a new password using a reset token and logs the user
+// out of every session
+func (s *userService) ResetPassword(token, newPassword string) error {
+	if len(newPassword) < 6 {
+		return errors.New("password must be at least 6 characters")
 	}
 
-	return "", errors.New("invalid token")
+	record, err := s.tokens.ConsumeActionToken(models.PurposePasswordReset, hashActionToken(token))
+	if err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByID(record.UserID)
+	if err != nil {
+		return repository.ErrActionTokenInvalid
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hashedPassword)
+
+	return s.revokeAllSessions(user)
 }
 
-// generateToken creates a JWT token for a user
-func generateToken(userID string, expiration time.Duration) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(expiration).Unix(),
-		"iat":     time.Now().Unix(),
+// ChangePassword sets a new password after checking the current one. Every
+// existing session is revoked and a fresh token pair is returned so the
+// caller stays logged in.
+func (s *userService) ChangePassword(id, currentPassword, newPassword string) (string, string, error) {
+	if len(newPassword) < 6 {
+		return "", "", errors.New("password must be at least 6 characters")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return "", "", err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return "", "", errors.New("current password is incorrect")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", err
+	}
+	user.Password = string(hashedPassword)
+
+	if err := s.revokeAllSessions(user); err != nil {
+		return "", "", err
+	}
+	return s.issueTokenPair(user)
+}
+
+// ChangeEmail starts an email change after checking the user's password. The
+// address is only updated once the token sent to the new address is confirmed.
+func (s *userService) ChangeEmail(id, password, newEmail string) error {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return errors.New("password is incorrect")
+	}
+	if newEmail == user.Email {
+		return errors.New("new email is the same as the current email")
+	}
+	if existing, _ := s.repo.GetByEmail(newEmail); existing != nil {
+		return errors.New("user with this email already exists")
+	}
+
+	token, hash, err := newActionToken()
+	if err != nil {
+		return err
+	}
+
+	record := &models.ActionToken{
+		UserID:    user.ID,
+		Purpose:   models.PurposeEmailChange,
+		TokenHash: hash,
+		Data:      newEmail,
+		ExpiresAt: time.Now().Add(emailChangeTTL),
+	}
+	if err := s.tokens.CreateActionToken(record); err != nil {
+		return err
+	}
+
+	return s.notifier.SendEmailChange(newEmail, token, record.ExpiresAt)
+}
+
+// ConfirmEmailChange applies a pending email change using the token sent to
+// the new address
+func (s *userService) ConfirmEmailChange(token string) (*models.User, error) {
+	record, err := s.tokens.ConsumeActionToken(models.PurposeEmailChange, hashActionToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(record.UserID)
+	if err != nil {
+		return nil, repository.ErrActionTokenInvalid
+	}
+
+	// The address may have been registered since the change was requested
+	if existing, _ := s.repo.GetByEmail(record.Data); existing != nil {
+		return nil, errors.New("user with this email already exists")
+	}
+
+	// Confirming the token proves ownership of the new address
+	user.Email = record.Data
+	user.EmailVerified = true
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// sendEmailVerification issues a verification token for the user's email
+func (s *userService) sendEmailVerification(user *models.User) error {
+	token, hash, err := newActionToken()
+	if err != nil {
+		return err
+	}
+
+	record := &models.ActionToken{
+		UserID:    user.ID,
+		Purpose:   models.PurposeVerifyEmail,
+		TokenHash: hash,
+		Data:      user.Email,
+		ExpiresAt: time.Now().Add(verifyEmailTTL),
+	}
+	if err := s.tokens.CreateActionToken(record); err != nil {
+		return err
+	}
+
+	return s.notifier.SendEmailVerification(user.Email, token, record.ExpiresAt)
+}
+
+// VerifyEmail marks the user's email as verified using a verification token
+func (s *userService) VerifyEmail(token string) (*models.User, error) {
+	record, err := s.tokens.ConsumeActionToken(models.PurposeVerifyEmail, hashActionToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(record.UserID)
+	if err != nil {
+		return nil, repository.ErrActionTokenInvalid
+	}
+
+	// A token sent before an email change does not verify the new address
+	if user.Email != record.Data {
+		return nil, repository.ErrActionTokenInvalid
+	}
+
+	user.EmailVerified = true
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ResendEmailVerification sends a new verification token, invalidating earlier ones
+func (s *userService) ResendEmailVerification(id string) error {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return errors.New("email is already verified")
+	}
+
+	return s.sendEmailVerification(user)
+}
+
+// ListUsers returns users for admins, including deactivated ones
+func (s *userService) ListUsers(filter UserListFilter, page, pageSize int) ([]*models.User, int64, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	return s.repo.List(repository.UserFilter{
+		Email:         strings.TrimSpace(filter.Email),
+		Name:          strings.TrimSpace(filter.Name),
+		IsActive:      filter.IsActive,
+		CreatedAfter:  filter.CreatedAfter,
+		CreatedBefore: filter.CreatedBefore,
+	}, page, pageSize)
+}
+
+// DeactivateUser blocks a user from logging in and revokes their sessions.
+// Unlike DeleteUser it can be undone with ReactivateUser. Deleted users can
+// be deactivated too, as erasure starts by deactivating the user.
+func (s *userService) DeactivateUser(id string) (*models.User, error) {
+	if err := s.repo.SetActive(id, false); err != nil {
+		return nil, err
+	}
+	if err := s.tokens.RevokeUserTokens(id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByIDIncludeDeleted(id)
+}
+
+// ReactivateUser lets a deactivated user log in again
+func (s *userService) ReactivateUser(id string) (*models.User, error) {
+	if err := s.repo.SetActive(id, true); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+// ExportUserData returns the user record, login history and linked
+// identities for a personal data export. Deactivated and deleted users are
+// included, since they can still ask for their data.
+func (s *userService) ExportUserData(id string) (*UserDataExport, error) {
+	user, err := s.repo.GetByIDIncludeDeleted(id)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.attempts.ListForUser(user.ID, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := s.identities.ListByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserDataExport{User: user, LoginAttempts: attempts, Identities: identities}, nil
+}
+
+// EraseUser permanently deletes the user and all data stored with them.
+// Unlike DeleteUser nothing is kept, so the user's tokens stop verifying
+// because the user can no longer be found.
+func (s *userService) EraseUser(id string) error {
+	return s.repo.Erase(id)
 }
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+
+	"jumia-clone-backend/services/user-service/internal/models"
+)
+
+func TestRoleChangeRevokesTokens(t *testing.T) {
+	keys, err := NewKeySet(KeyConfig{Algorithm: AlgorithmHS256, KeyID: "test", Secret: "test-secret"})
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	repo := &loginUserRepository{user: &models.User{ID: "user-1", Role: models.RoleAdmin}}
+	s := &userService{repo: repo, tokens: openDenylist{}, keys: keys}
+
+	adminToken, err := s.issueAccessToken(repo.user)
+	if err != nil {
+		t.Fatalf("issueAccessToken: %v", err)
+	}
+	if _, role, err := s.VerifyToken(adminToken); err != nil || role != models.RoleAdmin {
+		t.Fatalf("before demotion: role = %q, err = %v, want admin", role, err)
+	}
+
+	if _, err := s.UpdateUserRole("user-1", models.RoleCustomer); err != nil {
+		t.Fatalf("UpdateUserRole: %v", err)
+	}
+	if _, role, err := s.VerifyToken(adminToken); err == nil {
+		t.Errorf("token issued before demotion still verifies with role %q", role)
+	}
+
+	customerToken, err := s.issueAccessToken(repo.user)
+	if err != nil {
+		t.Fatalf("issueAccessToken: %v", err)
+	}
+	if _, role, err := s.VerifyToken(customerToken); err != nil || role != models.RoleCustomer {
+		t.Errorf("after demotion: role = %q, err = %v, want customer", role, err)
+	}
+}
+
+func TestVerifyTokenReturnsStoredRole(t *testing.T) {
+	keys, err := NewKeySet(KeyConfig{Algorithm: AlgorithmHS256, KeyID: "test", Secret: "test-secret"})
+	if err != nil {
+		t.Fatalf("NewKeySet: %v", err)
+	}
+	repo := &loginUserRepository{user: &models.User{ID: "user-1", Role: models.RoleCustomer}}
+	s := &userService{repo: repo, tokens: openDenylist{}, keys: keys}
+
+	// A token whose role claim no longer matches the user's row
+	token, _, err := keys.generateToken(&models.User{ID: "user-1", Role: models.RoleAdmin}, tokenTypeAccess, accessTokenTTL)
+	if err != nil {
+		t.Fatalf("generateToken: %v", err)
+	}
+	if _, role, err := s.VerifyToken(token); err != nil || role != models.RoleCustomer {
+		t.Errorf("role = %q, err = %v, want customer", role, err)
+	}
+}